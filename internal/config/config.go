@@ -6,12 +6,26 @@ import (
 	"os"
 	"path/filepath"
 	"slices"
+	"time"
 )
 
 var (
 	Dir  string
 	Port int
 	help bool
+
+	// Получатели уведомлений о низких остатках
+	NotifyWebhook string
+	NotifyFile    string
+
+	// Периодичность списания просроченных партий
+	ExpiryCheckInterval time.Duration
+
+	// Метод расчета себестоимости: fifo или average
+	CostingMethod string
+
+	// Ставка налога в процентах, включенного в цены меню
+	TaxRate float64
 )
 
 var (
@@ -20,28 +34,58 @@ var (
 	DebugLogPath = "logs/debug.log"
 )
 
+// Файлы данных, которые создаются при запуске, если их еще нет
+var dataFiles = []string{
+	"menu.json",
+	"order.json",
+	"inventory.json",
+	"movements.json",
+	"suppliers.json",
+	"purchase_orders.json",
+	"lots.json",
+	"stock_counts.json",
+	"daily_reports.json",
+	"customers.json",
+	"loyalty_rules.json",
+	"rewards.json",
+	"loyalty_ledger.json",
+	"promotions.json",
+}
+
 var helpTxt = `
 Coffee Shop Management System
 
 Usage:
-  hot-coffee [--port <N>] [--dir <S>] 
+  hot-coffee [--port <N>] [--dir <S>] [--notify-webhook <URL>] [--notify-file <S>] [--expiry-check-interval <D>] [--costing-method <S>] [--tax-rate <N>]
   hot-coffee --help
 
 Options:
-  --help       Show this screen.
-  --port N     Port number.
-  --dir S      Path to the data directory
+  --help               Show this screen.
+  --port N             Port number.
+  --dir S              Path to the data directory
+  --notify-webhook URL Webhook for low-stock alerts
+  --notify-file S      File to append low-stock alerts to
+  --expiry-check-interval D
+                       How often expired lots are written off (default 1h)
+  --costing-method S   Inventory costing method: fifo or average (default fifo)
+  --tax-rate N         Tax rate in percent included in menu prices (default 0)
 `
 
 var usageTxt = `
 Usage:
-  hot-coffee [--port <N>] [--dir <S>] 
+  hot-coffee [--port <N>] [--dir <S>] [--notify-webhook <URL>] [--notify-file <S>] [--expiry-check-interval <D>] [--costing-method <S>] [--tax-rate <N>]
   hot-coffee --help
 
 Options:
-  --help       Show help.
-  --port N     Port number.
-  --dir S      Path to the data directory
+  --help               Show help.
+  --port N             Port number.
+  --dir S              Path to the data directory
+  --notify-webhook URL Webhook for low-stock alerts
+  --notify-file S      File to append low-stock alerts to
+  --expiry-check-interval D
+                       How often expired lots are written off (default 1h)
+  --costing-method S   Inventory costing method: fifo or average (default fifo)
+  --tax-rate N         Tax rate in percent included in menu prices (default 0)
 `
 
 // Инициализация флагов командной строки
@@ -53,6 +97,11 @@ func init() {
 	flag.StringVar(&Dir, "dir", "./data", "Path to the data directory.")
 	flag.IntVar(&Port, "port", 8080, "Port number.")
 	flag.BoolVar(&help, "help", false, "Show help.")
+	flag.StringVar(&NotifyWebhook, "notify-webhook", "", "Webhook for low-stock alerts.")
+	flag.StringVar(&NotifyFile, "notify-file", "", "File to append low-stock alerts to.")
+	flag.DurationVar(&ExpiryCheckInterval, "expiry-check-interval", time.Hour, "How often expired lots are written off.")
+	flag.StringVar(&CostingMethod, "costing-method", "fifo", "Inventory costing method: fifo or average.")
+	flag.Float64Var(&TaxRate, "tax-rate", 0, "Tax rate in percent included in menu prices.")
 	flag.Parse()
 
 	// Если задан флаг --help, выводим справку и выходим
@@ -70,14 +119,10 @@ func InitConfig() error {
 	}
 
 	// Создаем файлы, если их еще нет
-	if err := createFileIfNotExists("menu.json"); err != nil {
-		return err
-	}
-	if err := createFileIfNotExists("order.json"); err != nil {
-		return err
-	}
-	if err := createFileIfNotExists("inventory.json"); err != nil {
-		return err
+	for _, fileName := range dataFiles {
+		if err := createFileIfNotExists(fileName); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -95,6 +140,21 @@ func checkFlags() error {
 		return fmt.Errorf("Port number must be in the range [1024, 49151]\n%s", usageTxt)
 	}
 
+	// Проверяем периодичность списания просроченных партий
+	if ExpiryCheckInterval <= 0 {
+		return fmt.Errorf("Expiry check interval must be positive\n%s", usageTxt)
+	}
+
+	// Проверяем метод расчета себестоимости
+	if CostingMethod != "fifo" && CostingMethod != "average" {
+		return fmt.Errorf("Costing method must be fifo or average\n%s", usageTxt)
+	}
+
+	// Проверяем ставку налога
+	if TaxRate < 0 || TaxRate > 100 {
+		return fmt.Errorf("Tax rate must be in the range [0, 100]\n%s", usageTxt)
+	}
+
 	// Проверяем существование и доступность директории с данными
 	if stat, err := os.Stat(Dir); err != nil || !stat.IsDir() {
 		return fmt.Errorf("Invalid data directory: %s\n%s", Dir, usageTxt)
@@ -4,52 +4,263 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
 	"os"
 	"path/filepath"
+	"slices"
+	"sort"
+	"time"
 
 	"hot-coffee/internal/config"
 	"hot-coffee/internal/domain"
 )
 
-// GetTotalSales вычисляет общую сумму продаж на основе завершенных заказов
-func (j *JsonDB) GetTotalSales() (float64, error) {
-	// Собираем путь к файлу order.json
-	path := filepath.Join(config.Dir, "order.json")
-
-	// Открываем файл
-	file, err := os.Open(path)
+// GetTotalSales вычисляет общую сумму продаж на основе завершенных заказов за период [from, to)
+func (j *JsonDB) GetTotalSales(from, to time.Time) (float64, error) {
+	// Читаем завершенные заказы за период
+	orders, err := j.readCompletedOrders(from, to)
 	if err != nil {
 		return 0, err
 	}
-	defer file.Close()
 
-	// Декодируем содержимое файла в срез структур domain.Order
-	var orders []domain.Order
-	if err := json.NewDecoder(file).Decode(&orders); err != nil {
+	// Читаем цены позиций меню
+	prices, err := j.readMenuPrices()
+	if err != nil {
 		return 0, err
 	}
 
 	// Вычисляем общую сумму продаж
 	totalSales := 0.0
 	for _, order := range orders {
-		if order.Status == domain.StatusCompleted {
-			for _, item := range order.Items {
-				// Получаем цену товара
-				price, err := j.getItemPrice(item.ProductID)
-				if err != nil {
-					return 0, fmt.Errorf("could not get price for item %s: %w", item.ProductID, err)
-				}
-				totalSales += float64(item.Quantity) * price
-			}
+		orderTotal, err := orderTotal(order, prices)
+		if err != nil {
+			return 0, err
 		}
+		totalSales += orderTotal
 	}
 	return totalSales, nil
 }
 
-// GetPopularItems находит самые популярные товары (по количеству проданных) из завершенных заказов
-func (j *JsonDB) GetPopularItems() ([]domain.ProductSales, error) {
+// GetSalesByPeriod разбивает сумму продаж за период [from, to) на интервалы groupBy в часовом поясе loc.
+// Интервалы без продаж включаются с нулевой суммой.
+func (j *JsonDB) GetSalesByPeriod(from, to time.Time, groupBy domain.ReportGroupBy, loc *time.Location) ([]domain.SalesPeriod, error) {
+	orders, err := j.readCompletedOrders(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	prices, err := j.readMenuPrices()
+	if err != nil {
+		return nil, err
+	}
+
+	// Суммируем продажи по началу интервала
+	byPeriod := make(map[time.Time]*domain.SalesPeriod)
+	first, last := from, to
+	for _, order := range orders {
+		orderTotal, err := orderTotal(order, prices)
+		if err != nil {
+			return nil, err
+		}
+
+		start := groupBy.Truncate(order.CreatedAt, loc)
+		period, ok := byPeriod[start]
+		if !ok {
+			period = &domain.SalesPeriod{PeriodStart: start}
+			byPeriod[start] = period
+		}
+		period.TotalSales += orderTotal
+		period.Orders++
+
+		if from.IsZero() && (first.IsZero() || order.CreatedAt.Before(first)) {
+			first = order.CreatedAt
+		}
+		if to.IsZero() && (last.IsZero() || !order.CreatedAt.Before(last)) {
+			last = order.CreatedAt.Add(time.Nanosecond)
+		}
+	}
+
+	periods := make([]domain.SalesPeriod, 0, len(byPeriod))
+	if first.IsZero() || last.IsZero() {
+		return periods, nil
+	}
+
+	// Заполняем все интервалы от начала до конца периода
+	if groupBy.Periods(first, last, loc) > domain.MaxReportPeriods {
+		return nil, domain.ErrTooManyPeriods
+	}
+	for start := groupBy.Truncate(first, loc); start.Before(last); start = groupBy.Next(start) {
+		if period, ok := byPeriod[start]; ok {
+			periods = append(periods, *period)
+			continue
+		}
+		periods = append(periods, domain.SalesPeriod{PeriodStart: start})
+	}
+	return periods, nil
+}
+
+// GetPopularItems находит самые популярные товары (по количеству проданных) из завершенных заказов за период [from, to).
+// Комбо учитываются как отдельные позиции, а их компоненты дополнительно получают продажи в составе комбо.
+// Результат отсортирован по количеству, затем по выручке и ID товара.
+func (j *JsonDB) GetPopularItems(from, to time.Time) ([]domain.ProductSales, error) {
+	// Читаем завершенные заказы за период
+	orders, err := j.readCompletedOrders(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	// Читаем названия и цены позиций меню
+	menuItems, err := j.readMenuItems()
+	if err != nil {
+		return nil, err
+	}
+
+	lookup := menuLookup(menuItems)
+
+	// Создаем словарь для хранения продаж каждого товара
+	itemSales := make(map[string]*domain.ProductSales)
+	salesOf := func(productID string) *domain.ProductSales {
+		sales, ok := itemSales[productID]
+		if !ok {
+			sales = &domain.ProductSales{ProductID: productID}
+			if menuItem, ok := menuItems[productID]; ok {
+				sales.Name = menuItem.Name
+			}
+			itemSales[productID] = sales
+		}
+		return sales
+	}
+	for _, order := range orders {
+		for _, item := range order.Items {
+			sales := salesOf(item.ProductID)
+			sales.Quantity += item.Quantity
+			// Выручка удаленных из меню товаров без зафиксированной цены неизвестна
+			price, ok := item.UnitPrice(lookup)
+			if !ok {
+				continue
+			}
+			sales.Revenue += float64(item.Quantity) * price
+
+			// Распределяем выручку комбо между компонентами
+			menuItem, ok := menuItems[item.ProductID]
+			if !ok || !menuItem.IsBundle() {
+				continue
+			}
+			lines, err := menuItem.BundleLines(item.Choices, lookup)
+			if err != nil {
+				continue
+			}
+			for i, revenue := range menuItem.BundleRevenue(price, lines, lookup) {
+				component := salesOf(lines[i].ProductID)
+				component.BundleQuantity += lines[i].Quantity * item.Quantity
+				component.BundleRevenue += revenue * float64(item.Quantity)
+			}
+		}
+	}
+
+	// Создаем срез структур domain.ProductSales с популярными товарами
+	popularItems := make([]domain.ProductSales, 0, len(itemSales))
+	for _, sales := range itemSales {
+		sales.BundleRevenue = math.Round(sales.BundleRevenue*100) / 100
+		popularItems = append(popularItems, *sales)
+	}
+	sort.Slice(popularItems, func(i, k int) bool {
+		if popularItems[i].Quantity != popularItems[k].Quantity {
+			return popularItems[i].Quantity > popularItems[k].Quantity
+		}
+		if popularItems[i].Revenue != popularItems[k].Revenue {
+			return popularItems[i].Revenue > popularItems[k].Revenue
+		}
+		return popularItems[i].ProductID < popularItems[k].ProductID
+	})
+	return popularItems, nil
+}
+
+// GetBasketReport считает средний чек, среднее количество позиций и совместные покупки по завершенным заказам за период [from, to).
+// Пары включают все позиции, встретившиеся вместе хотя бы в одном заказе; количество позиции в заказе на пары не влияет.
+func (j *JsonDB) GetBasketReport(from, to time.Time) (*domain.BasketReport, error) {
+	// Читаем завершенные заказы за период
+	orders, err := j.readCompletedOrders(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	// Читаем названия и цены позиций меню
+	menuItems, err := j.readMenuItems()
+	if err != nil {
+		return nil, err
+	}
+
+	report := &domain.BasketReport{Orders: len(orders), Pairs: make([]domain.ProductPair, 0)}
+	if len(orders) == 0 {
+		return report, nil
+	}
+
+	// Считаем заказы с каждой позицией и с каждой парой позиций
+	items := 0
+	productOrders := make(map[string]int)
+	pairOrders := make(map[[2]string]int)
+	for _, order := range orders {
+		products := make([]string, 0, len(order.Items))
+		for _, item := range order.Items {
+			items += item.Quantity
+			// Выручка удаленных из меню товаров без зафиксированной цены неизвестна
+			if price, ok := item.UnitPrice(menuLookup(menuItems)); ok {
+				report.TotalSales += float64(item.Quantity) * price
+			}
+			if !slices.Contains(products, item.ProductID) {
+				products = append(products, item.ProductID)
+			}
+		}
+
+		sort.Strings(products)
+		for i, a := range products {
+			productOrders[a]++
+			for _, b := range products[i+1:] {
+				pairOrders[[2]string{a, b}]++
+			}
+		}
+	}
+
+	total := float64(len(orders))
+	report.AverageOrderValue = report.TotalSales / total
+	report.AverageItems = float64(items) / total
+	for pair, count := range pairOrders {
+		supportA := float64(productOrders[pair[0]]) / total
+		supportB := float64(productOrders[pair[1]]) / total
+		support := float64(count) / total
+		report.Pairs = append(report.Pairs, domain.ProductPair{
+			ProductA:     pair[0],
+			NameA:        menuItems[pair[0]].Name,
+			ProductB:     pair[1],
+			NameB:        menuItems[pair[1]].Name,
+			Orders:       count,
+			Support:      support,
+			ConfidenceAB: support / supportA,
+			ConfidenceBA: support / supportB,
+			Lift:         support / (supportA * supportB),
+		})
+	}
+	sort.Slice(report.Pairs, func(i, k int) bool {
+		if report.Pairs[i].Orders != report.Pairs[k].Orders {
+			return report.Pairs[i].Orders > report.Pairs[k].Orders
+		}
+		if report.Pairs[i].Lift != report.Pairs[k].Lift {
+			return report.Pairs[i].Lift > report.Pairs[k].Lift
+		}
+		if report.Pairs[i].ProductA != report.Pairs[k].ProductA {
+			return report.Pairs[i].ProductA < report.Pairs[k].ProductA
+		}
+		return report.Pairs[i].ProductB < report.Pairs[k].ProductB
+	})
+	return report, nil
+}
+
+// readCompletedOrders читает завершенные заказы, созданные в период [from, to); нулевые границы не ограничивают период
+func (j *JsonDB) readCompletedOrders(from, to time.Time) ([]domain.Order, error) {
 	// Собираем путь к файлу order.json
 	path := filepath.Join(config.Dir, "order.json")
+
 	// Открываем файл
 	file, err := os.Open(path)
 	if err != nil {
@@ -63,51 +274,85 @@ func (j *JsonDB) GetPopularItems() ([]domain.ProductSales, error) {
 		return nil, err
 	}
 
-	// Создаем словарь для хранения количества продаж каждого товара
-	itemSales := make(map[string]int)
+	completed := make([]domain.Order, 0, len(orders))
 	for _, order := range orders {
-		if order.Status == domain.StatusCompleted {
-			for _, item := range order.Items {
-				itemSales[item.ProductID] += item.Quantity
-			}
+		if order.Status != domain.StatusCompleted {
+			continue
 		}
+		if !from.IsZero() && order.CreatedAt.Before(from) {
+			continue
+		}
+		if !to.IsZero() && !order.CreatedAt.Before(to) {
+			continue
+		}
+		completed = append(completed, order)
 	}
-
-	// Создаем срез структур domain.ProductSales с популярными товарами
-	popularItems := make([]domain.ProductSales, 0)
-	for itemID, salesCount := range itemSales {
-		popularItems = append(popularItems, domain.ProductSales{
-			ProductID: itemID,
-			Quantity:  salesCount,
-		})
-	}
-	return popularItems, nil
+	return completed, nil
 }
 
-// getItemPrice получает цену определенного товара
-func (j *JsonDB) getItemPrice(productID string) (float64, error) {
+// readMenuItems читает позиции меню по их ID
+func (j *JsonDB) readMenuItems() (map[string]domain.MenuItem, error) {
 	// Собираем путь к файлу menu.json
 	path := filepath.Join(config.Dir, "menu.json")
 
 	// Открываем файл
 	file, err := os.Open(path)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 	defer file.Close()
 
 	// Декодируем содержимое файла в срез структур domain.MenuItem
 	var menuItems []domain.MenuItem
 	if err := json.NewDecoder(file).Decode(&menuItems); err != nil {
-		return 0, err
+		return nil, err
 	}
 
-	// Находим и возвращаем цену товара
+	byID := make(map[string]domain.MenuItem, len(menuItems))
 	for _, item := range menuItems {
-		if item.ID == productID {
-			return item.Price, nil
+		byID[item.ID] = item
+	}
+	return byID, nil
+}
+
+// menuLookup ищет позицию меню по ID
+func menuLookup(menuItems map[string]domain.MenuItem) func(id string) *domain.MenuItem {
+	return func(id string) *domain.MenuItem {
+		if menuItem, ok := menuItems[id]; ok {
+			return &menuItem
 		}
+		return nil
+	}
+}
+
+// readMenuPrices читает цены позиций меню
+func (j *JsonDB) readMenuPrices() (map[string]float64, error) {
+	menuItems, err := j.readMenuItems()
+	if err != nil {
+		return nil, err
+	}
+
+	prices := make(map[string]float64, len(menuItems))
+	for id, item := range menuItems {
+		prices[id] = item.Price
 	}
+	return prices, nil
+}
 
-	return 0, errors.New("item not found")
+// orderTotal вычисляет сумму заказа по зафиксированным в нем ценам, а для старых заказов — по ценам меню
+func orderTotal(order domain.Order, prices map[string]float64) (float64, error) {
+	total := 0.0
+	for _, item := range order.Items {
+		// Получаем цену товара
+		if item.Price != nil {
+			total += float64(item.Quantity) * *item.Price
+			continue
+		}
+		price, ok := prices[item.ProductID]
+		if !ok {
+			return 0, fmt.Errorf("could not get price for item %s: %w", item.ProductID, errors.New("item not found"))
+		}
+		total += float64(item.Quantity) * price
+	}
+	return total, nil
 }
@@ -0,0 +1,79 @@
+package jsondb
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+
+	"hot-coffee/internal/config"
+	"hot-coffee/internal/domain"
+)
+
+// Получение клиентов из файла customers.json
+func (j *JsonDB) GetCustomers() ([]byte, error) {
+	path := filepath.Join(config.Dir, "customers.json")
+
+	file, err := os.OpenFile(path, os.O_RDONLY, 0o755)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// Сохранение клиентов в файл customers.json
+func (j *JsonDB) SaveCustomers(data []byte) error {
+	path := filepath.Join(config.Dir, "customers.json")
+
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o755)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = file.Write(data)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Десериализация массива клиентов из JSON
+func (j *JsonDB) UnmarshalJsonCustomers(data []byte) ([]*domain.Customer, error) {
+	var customers []*domain.Customer
+	err := json.Unmarshal(data, &customers)
+	if err != nil {
+		return nil, err
+	}
+
+	return customers, nil
+}
+
+// Сериализация массива клиентов в JSON
+func (j *JsonDB) MarshalJsonCustomers(customers []*domain.Customer) ([]byte, error) {
+	return json.Marshal(customers)
+}
+
+// Десериализация одного клиента из JSON
+func (j *JsonDB) UnmarshalJsonCustomer(data []byte) (*domain.Customer, error) {
+	var customer domain.Customer
+	err := json.Unmarshal(data, &customer)
+	if err != nil {
+		return nil, err
+	}
+
+	return &customer, nil
+}
+
+// Сериализация одного клиента в JSON
+func (j *JsonDB) MarshalJsonCustomer(customer *domain.Customer) ([]byte, error) {
+	return json.Marshal(customer)
+}
@@ -0,0 +1,63 @@
+package jsondb
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+
+	"hot-coffee/internal/config"
+	"hot-coffee/internal/domain"
+)
+
+// Получение отчетов о закрытии дня из файла daily_reports.json
+func (j *JsonDB) GetDailyReports() ([]byte, error) {
+	path := filepath.Join(config.Dir, "daily_reports.json")
+
+	file, err := os.OpenFile(path, os.O_RDONLY, 0o755)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// Сохранение отчетов о закрытии дня в файл daily_reports.json
+func (j *JsonDB) SaveDailyReports(data []byte) error {
+	path := filepath.Join(config.Dir, "daily_reports.json")
+
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o755)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = file.Write(data)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Десериализация массива отчетов о закрытии дня из JSON
+func (j *JsonDB) UnmarshalJsonDailyReports(data []byte) ([]*domain.DailyReport, error) {
+	var reports []*domain.DailyReport
+	err := json.Unmarshal(data, &reports)
+	if err != nil {
+		return nil, err
+	}
+
+	return reports, nil
+}
+
+// Сериализация массива отчетов о закрытии дня в JSON
+func (j *JsonDB) MarshalJsonDailyReports(reports []*domain.DailyReport) ([]byte, error) {
+	return json.Marshal(reports)
+}
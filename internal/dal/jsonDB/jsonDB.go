@@ -1,7 +1,21 @@
 package jsondb
 
-type JsonDB struct{}
+import "sync"
+
+type JsonDB struct {
+	mu sync.Mutex
+}
 
 func NewJsonDB() *JsonDB {
 	return &JsonDB{}
 }
+
+// Lock блокирует хранилище на время изменения данных
+func (j *JsonDB) Lock() {
+	j.mu.Lock()
+}
+
+// Unlock снимает блокировку хранилища
+func (j *JsonDB) Unlock() {
+	j.mu.Unlock()
+}
@@ -0,0 +1,63 @@
+package jsondb
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+
+	"hot-coffee/internal/config"
+	"hot-coffee/internal/domain"
+)
+
+// Получение партий из файла lots.json
+func (j *JsonDB) GetLots() ([]byte, error) {
+	path := filepath.Join(config.Dir, "lots.json")
+
+	file, err := os.OpenFile(path, os.O_RDONLY, 0o755)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// Сохранение партий в файл lots.json
+func (j *JsonDB) SaveLots(data []byte) error {
+	path := filepath.Join(config.Dir, "lots.json")
+
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o755)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = file.Write(data)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Десериализация массива партий из JSON
+func (j *JsonDB) UnmarshalJsonLots(data []byte) ([]*domain.InventoryLot, error) {
+	var lots []*domain.InventoryLot
+	err := json.Unmarshal(data, &lots)
+	if err != nil {
+		return nil, err
+	}
+
+	return lots, nil
+}
+
+// Сериализация массива партий в JSON
+func (j *JsonDB) MarshalJsonLots(lots []*domain.InventoryLot) ([]byte, error) {
+	return json.Marshal(lots)
+}
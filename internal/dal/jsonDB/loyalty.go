@@ -0,0 +1,189 @@
+package jsondb
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+
+	"hot-coffee/internal/config"
+	"hot-coffee/internal/domain"
+)
+
+// Получение правил начисления баллов из файла loyalty_rules.json
+func (j *JsonDB) GetLoyaltyRules() ([]byte, error) {
+	path := filepath.Join(config.Dir, "loyalty_rules.json")
+
+	file, err := os.OpenFile(path, os.O_RDONLY, 0o755)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// Сохранение правил начисления баллов в файл loyalty_rules.json
+func (j *JsonDB) SaveLoyaltyRules(data []byte) error {
+	path := filepath.Join(config.Dir, "loyalty_rules.json")
+
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o755)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = file.Write(data)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Десериализация правил начисления баллов из JSON
+func (j *JsonDB) UnmarshalJsonLoyaltyRules(data []byte) ([]*domain.EarnRule, error) {
+	var rules []*domain.EarnRule
+	err := json.Unmarshal(data, &rules)
+	if err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+// Сериализация правил начисления баллов в JSON
+func (j *JsonDB) MarshalJsonLoyaltyRules(rules []*domain.EarnRule) ([]byte, error) {
+	return json.Marshal(rules)
+}
+
+// Десериализация одного правила начисления из JSON
+func (j *JsonDB) UnmarshalJsonLoyaltyRule(data []byte) (*domain.EarnRule, error) {
+	var rule domain.EarnRule
+	err := json.Unmarshal(data, &rule)
+	if err != nil {
+		return nil, err
+	}
+
+	return &rule, nil
+}
+
+// Получение наград из файла rewards.json
+func (j *JsonDB) GetRewards() ([]byte, error) {
+	path := filepath.Join(config.Dir, "rewards.json")
+
+	file, err := os.OpenFile(path, os.O_RDONLY, 0o755)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// Сохранение наград в файл rewards.json
+func (j *JsonDB) SaveRewards(data []byte) error {
+	path := filepath.Join(config.Dir, "rewards.json")
+
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o755)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = file.Write(data)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Десериализация наград из JSON
+func (j *JsonDB) UnmarshalJsonRewards(data []byte) ([]*domain.Reward, error) {
+	var rewards []*domain.Reward
+	err := json.Unmarshal(data, &rewards)
+	if err != nil {
+		return nil, err
+	}
+
+	return rewards, nil
+}
+
+// Сериализация наград в JSON
+func (j *JsonDB) MarshalJsonRewards(rewards []*domain.Reward) ([]byte, error) {
+	return json.Marshal(rewards)
+}
+
+// Десериализация одной награды из JSON
+func (j *JsonDB) UnmarshalJsonReward(data []byte) (*domain.Reward, error) {
+	var reward domain.Reward
+	err := json.Unmarshal(data, &reward)
+	if err != nil {
+		return nil, err
+	}
+
+	return &reward, nil
+}
+
+// Получение журнала баллов из файла loyalty_ledger.json
+func (j *JsonDB) GetLoyaltyLedger() ([]byte, error) {
+	path := filepath.Join(config.Dir, "loyalty_ledger.json")
+
+	file, err := os.OpenFile(path, os.O_RDONLY, 0o755)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// Сохранение журнала баллов в файл loyalty_ledger.json
+func (j *JsonDB) SaveLoyaltyLedger(data []byte) error {
+	path := filepath.Join(config.Dir, "loyalty_ledger.json")
+
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o755)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = file.Write(data)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Десериализация журнала баллов из JSON
+func (j *JsonDB) UnmarshalJsonLoyaltyLedger(data []byte) ([]*domain.LoyaltyEntry, error) {
+	var entries []*domain.LoyaltyEntry
+	err := json.Unmarshal(data, &entries)
+	if err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// Сериализация журнала баллов в JSON
+func (j *JsonDB) MarshalJsonLoyaltyLedger(entries []*domain.LoyaltyEntry) ([]byte, error) {
+	return json.Marshal(entries)
+}
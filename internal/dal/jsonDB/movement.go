@@ -0,0 +1,63 @@
+package jsondb
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+
+	"hot-coffee/internal/config"
+	"hot-coffee/internal/domain"
+)
+
+// Получение журнала движений из файла movements.json
+func (j *JsonDB) GetMovements() ([]byte, error) {
+	path := filepath.Join(config.Dir, "movements.json")
+
+	file, err := os.OpenFile(path, os.O_RDONLY, 0o755)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// Сохранение журнала движений в файл movements.json
+func (j *JsonDB) SaveMovements(data []byte) error {
+	path := filepath.Join(config.Dir, "movements.json")
+
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o755)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = file.Write(data)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Десериализация журнала движений из JSON
+func (j *JsonDB) UnmarshalJsonMovements(data []byte) ([]*domain.StockMovement, error) {
+	var movements []*domain.StockMovement
+	err := json.Unmarshal(data, &movements)
+	if err != nil {
+		return nil, err
+	}
+
+	return movements, nil
+}
+
+// Сериализация журнала движений в JSON
+func (j *JsonDB) MarshalJsonMovements(movements []*domain.StockMovement) ([]byte, error) {
+	return json.Marshal(movements)
+}
@@ -0,0 +1,79 @@
+package jsondb
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+
+	"hot-coffee/internal/config"
+	"hot-coffee/internal/domain"
+)
+
+// Получение акций из файла promotions.json
+func (j *JsonDB) GetPromotions() ([]byte, error) {
+	path := filepath.Join(config.Dir, "promotions.json")
+
+	file, err := os.OpenFile(path, os.O_RDONLY, 0o755)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// Сохранение акций в файл promotions.json
+func (j *JsonDB) SavePromotions(data []byte) error {
+	path := filepath.Join(config.Dir, "promotions.json")
+
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o755)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = file.Write(data)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Десериализация массива акций из JSON
+func (j *JsonDB) UnmarshalJsonPromotions(data []byte) ([]*domain.Promotion, error) {
+	var promotions []*domain.Promotion
+	err := json.Unmarshal(data, &promotions)
+	if err != nil {
+		return nil, err
+	}
+
+	return promotions, nil
+}
+
+// Сериализация массива акций в JSON
+func (j *JsonDB) MarshalJsonPromotions(promotions []*domain.Promotion) ([]byte, error) {
+	return json.Marshal(promotions)
+}
+
+// Десериализация одной акции из JSON
+func (j *JsonDB) UnmarshalJsonPromotion(data []byte) (*domain.Promotion, error) {
+	var promotion domain.Promotion
+	err := json.Unmarshal(data, &promotion)
+	if err != nil {
+		return nil, err
+	}
+
+	return &promotion, nil
+}
+
+// Сериализация одной акции в JSON
+func (j *JsonDB) MarshalJsonPromotion(promotion *domain.Promotion) ([]byte, error) {
+	return json.Marshal(promotion)
+}
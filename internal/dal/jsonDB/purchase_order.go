@@ -0,0 +1,68 @@
+package jsondb
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+
+	"hot-coffee/internal/config"
+	"hot-coffee/internal/domain"
+)
+
+// Получение заказов поставщикам из файла purchase_orders.json
+func (j *JsonDB) GetPurchaseOrders() ([]byte, error) {
+	path := filepath.Join(config.Dir, "purchase_orders.json")
+
+	file, err := os.OpenFile(path, os.O_RDONLY, 0o755)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// Сохранение заказов поставщикам в файл purchase_orders.json
+func (j *JsonDB) SavePurchaseOrders(data []byte) error {
+	path := filepath.Join(config.Dir, "purchase_orders.json")
+
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o755)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = file.Write(data)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Десериализация массива заказов поставщикам из JSON
+func (j *JsonDB) UnmarshalJsonPurchaseOrders(data []byte) ([]*domain.PurchaseOrder, error) {
+	var purchaseOrders []*domain.PurchaseOrder
+	err := json.Unmarshal(data, &purchaseOrders)
+	if err != nil {
+		return nil, err
+	}
+
+	return purchaseOrders, nil
+}
+
+// Сериализация массива заказов поставщикам в JSON
+func (j *JsonDB) MarshalJsonPurchaseOrders(purchaseOrders []*domain.PurchaseOrder) ([]byte, error) {
+	return json.Marshal(purchaseOrders)
+}
+
+// Сериализация одного заказа поставщику в JSON
+func (j *JsonDB) MarshalJsonPurchaseOrder(purchaseOrder *domain.PurchaseOrder) ([]byte, error) {
+	return json.Marshal(purchaseOrder)
+}
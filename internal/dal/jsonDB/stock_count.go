@@ -0,0 +1,63 @@
+package jsondb
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+
+	"hot-coffee/internal/config"
+	"hot-coffee/internal/domain"
+)
+
+// Получение инвентаризаций из файла stock_counts.json
+func (j *JsonDB) GetStockCounts() ([]byte, error) {
+	path := filepath.Join(config.Dir, "stock_counts.json")
+
+	file, err := os.OpenFile(path, os.O_RDONLY, 0o755)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// Сохранение инвентаризаций в файл stock_counts.json
+func (j *JsonDB) SaveStockCounts(data []byte) error {
+	path := filepath.Join(config.Dir, "stock_counts.json")
+
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o755)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = file.Write(data)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Десериализация массива инвентаризаций из JSON
+func (j *JsonDB) UnmarshalJsonStockCounts(data []byte) ([]*domain.StockCount, error) {
+	var stockCounts []*domain.StockCount
+	err := json.Unmarshal(data, &stockCounts)
+	if err != nil {
+		return nil, err
+	}
+
+	return stockCounts, nil
+}
+
+// Сериализация массива инвентаризаций в JSON
+func (j *JsonDB) MarshalJsonStockCounts(stockCounts []*domain.StockCount) ([]byte, error) {
+	return json.Marshal(stockCounts)
+}
@@ -0,0 +1,79 @@
+package jsondb
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+
+	"hot-coffee/internal/config"
+	"hot-coffee/internal/domain"
+)
+
+// Получение поставщиков из файла suppliers.json
+func (j *JsonDB) GetSuppliers() ([]byte, error) {
+	path := filepath.Join(config.Dir, "suppliers.json")
+
+	file, err := os.OpenFile(path, os.O_RDONLY, 0o755)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// Сохранение поставщиков в файл suppliers.json
+func (j *JsonDB) SaveSuppliers(data []byte) error {
+	path := filepath.Join(config.Dir, "suppliers.json")
+
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o755)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = file.Write(data)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Десериализация массива поставщиков из JSON
+func (j *JsonDB) UnmarshalJsonSuppliers(data []byte) ([]*domain.Supplier, error) {
+	var suppliers []*domain.Supplier
+	err := json.Unmarshal(data, &suppliers)
+	if err != nil {
+		return nil, err
+	}
+
+	return suppliers, nil
+}
+
+// Сериализация массива поставщиков в JSON
+func (j *JsonDB) MarshalJsonSuppliers(suppliers []*domain.Supplier) ([]byte, error) {
+	return json.Marshal(suppliers)
+}
+
+// Десериализация одного поставщика из JSON
+func (j *JsonDB) UnmarshalJsonSupplier(data []byte) (*domain.Supplier, error) {
+	var supplier domain.Supplier
+	err := json.Unmarshal(data, &supplier)
+	if err != nil {
+		return nil, err
+	}
+
+	return &supplier, nil
+}
+
+// Сериализация одного поставщика в JSON
+func (j *JsonDB) MarshalJsonSupplier(supplier *domain.Supplier) ([]byte, error) {
+	return json.Marshal(supplier)
+}
@@ -1,12 +1,29 @@
 package dal
 
-import "hot-coffee/internal/domain"
+import (
+	"time"
 
-// Общий интерфейс хранилища данных
+	"hot-coffee/internal/domain"
+)
+
+// Общий интерфейс хранилища данных.
+// Lock и Unlock защищают операции чтения-изменения-записи от параллельных запросов.
 type DataRepository interface {
+	Lock()
+	Unlock()
+
 	OrderRepository
 	MenuRepository
 	InventoryRepository
+	MovementRepository
+	SupplierRepository
+	CustomerRepository
+	LoyaltyRepository
+	PromotionRepository
+	PurchaseOrderRepository
+	LotRepository
+	StockCountRepository
+	DailyReportRepository
 	AgreggationRepository
 }
 
@@ -68,8 +85,203 @@ type InventoryRepository interface {
 	SaveInventoryItems(data []byte) error
 }
 
+// Интерфейс журнала движений по складу
+type MovementRepository interface {
+	// GetMovements получает все движения
+	GetMovements() ([]byte, error)
+
+	// SaveMovements сохраняет движения
+	SaveMovements([]byte) error
+
+	// UnmarshalJsonMovements десериализует движения из JSON
+	UnmarshalJsonMovements(data []byte) ([]*domain.StockMovement, error)
+
+	// MarshalJsonMovements сериализует движения в JSON
+	MarshalJsonMovements(movements []*domain.StockMovement) ([]byte, error)
+}
+
+// Интерфейс хранилища поставщиков
+type SupplierRepository interface {
+	// GetSuppliers получает всех поставщиков
+	GetSuppliers() ([]byte, error)
+
+	// SaveSuppliers сохраняет поставщиков
+	SaveSuppliers([]byte) error
+
+	// UnmarshalJsonSuppliers десериализует поставщиков из JSON
+	UnmarshalJsonSuppliers(data []byte) ([]*domain.Supplier, error)
+
+	// MarshalJsonSuppliers сериализует поставщиков в JSON
+	MarshalJsonSuppliers(suppliers []*domain.Supplier) ([]byte, error)
+
+	// UnmarshalJsonSupplier десериализует одного поставщика из JSON
+	UnmarshalJsonSupplier(data []byte) (*domain.Supplier, error)
+
+	// MarshalJsonSupplier сериализует одного поставщика в JSON
+	MarshalJsonSupplier(supplier *domain.Supplier) ([]byte, error)
+}
+
+// Интерфейс хранилища заказов поставщикам
+type PurchaseOrderRepository interface {
+	// GetPurchaseOrders получает все заказы поставщикам
+	GetPurchaseOrders() ([]byte, error)
+
+	// SavePurchaseOrders сохраняет заказы поставщикам
+	SavePurchaseOrders([]byte) error
+
+	// UnmarshalJsonPurchaseOrders десериализует заказы поставщикам из JSON
+	UnmarshalJsonPurchaseOrders(data []byte) ([]*domain.PurchaseOrder, error)
+
+	// MarshalJsonPurchaseOrders сериализует заказы поставщикам в JSON
+	MarshalJsonPurchaseOrders(purchaseOrders []*domain.PurchaseOrder) ([]byte, error)
+
+	// MarshalJsonPurchaseOrder сериализует один заказ поставщику в JSON
+	MarshalJsonPurchaseOrder(purchaseOrder *domain.PurchaseOrder) ([]byte, error)
+}
+
+// Интерфейс хранилища партий инвентаря
+type LotRepository interface {
+	// GetLots получает все партии
+	GetLots() ([]byte, error)
+
+	// SaveLots сохраняет партии
+	SaveLots([]byte) error
+
+	// UnmarshalJsonLots десериализует партии из JSON
+	UnmarshalJsonLots(data []byte) ([]*domain.InventoryLot, error)
+
+	// MarshalJsonLots сериализует партии в JSON
+	MarshalJsonLots(lots []*domain.InventoryLot) ([]byte, error)
+}
+
+// Интерфейс хранилища сессий инвентаризации
+type StockCountRepository interface {
+	// GetStockCounts получает все сессии инвентаризации
+	GetStockCounts() ([]byte, error)
+
+	// SaveStockCounts сохраняет сессии инвентаризации
+	SaveStockCounts([]byte) error
+
+	// UnmarshalJsonStockCounts десериализует сессии инвентаризации из JSON
+	UnmarshalJsonStockCounts(data []byte) ([]*domain.StockCount, error)
+
+	// MarshalJsonStockCounts сериализует сессии инвентаризации в JSON
+	MarshalJsonStockCounts(stockCounts []*domain.StockCount) ([]byte, error)
+}
+
+// Интерфейс хранилища клиентов
+type CustomerRepository interface {
+	// GetCustomers получает всех клиентов
+	GetCustomers() ([]byte, error)
+
+	// SaveCustomers сохраняет клиентов
+	SaveCustomers([]byte) error
+
+	// UnmarshalJsonCustomers десериализует клиентов из JSON
+	UnmarshalJsonCustomers(data []byte) ([]*domain.Customer, error)
+
+	// MarshalJsonCustomers сериализует клиентов в JSON
+	MarshalJsonCustomers(customers []*domain.Customer) ([]byte, error)
+
+	// UnmarshalJsonCustomer десериализует одного клиента из JSON
+	UnmarshalJsonCustomer(data []byte) (*domain.Customer, error)
+
+	// MarshalJsonCustomer сериализует одного клиента в JSON
+	MarshalJsonCustomer(customer *domain.Customer) ([]byte, error)
+}
+
+// Интерфейс хранилища программы лояльности: правил начисления, наград и журнала баллов
+type LoyaltyRepository interface {
+	// GetLoyaltyRules получает все правила начисления баллов
+	GetLoyaltyRules() ([]byte, error)
+
+	// SaveLoyaltyRules сохраняет правила начисления баллов
+	SaveLoyaltyRules([]byte) error
+
+	// UnmarshalJsonLoyaltyRules десериализует правила начисления из JSON
+	UnmarshalJsonLoyaltyRules(data []byte) ([]*domain.EarnRule, error)
+
+	// MarshalJsonLoyaltyRules сериализует правила начисления в JSON
+	MarshalJsonLoyaltyRules(rules []*domain.EarnRule) ([]byte, error)
+
+	// UnmarshalJsonLoyaltyRule десериализует одно правило начисления из JSON
+	UnmarshalJsonLoyaltyRule(data []byte) (*domain.EarnRule, error)
+
+	// GetRewards получает все награды
+	GetRewards() ([]byte, error)
+
+	// SaveRewards сохраняет награды
+	SaveRewards([]byte) error
+
+	// UnmarshalJsonRewards десериализует награды из JSON
+	UnmarshalJsonRewards(data []byte) ([]*domain.Reward, error)
+
+	// MarshalJsonRewards сериализует награды в JSON
+	MarshalJsonRewards(rewards []*domain.Reward) ([]byte, error)
+
+	// UnmarshalJsonReward десериализует одну награду из JSON
+	UnmarshalJsonReward(data []byte) (*domain.Reward, error)
+
+	// GetLoyaltyLedger получает журнал баллов
+	GetLoyaltyLedger() ([]byte, error)
+
+	// SaveLoyaltyLedger сохраняет журнал баллов
+	SaveLoyaltyLedger([]byte) error
+
+	// UnmarshalJsonLoyaltyLedger десериализует журнал баллов из JSON
+	UnmarshalJsonLoyaltyLedger(data []byte) ([]*domain.LoyaltyEntry, error)
+
+	// MarshalJsonLoyaltyLedger сериализует журнал баллов в JSON
+	MarshalJsonLoyaltyLedger(entries []*domain.LoyaltyEntry) ([]byte, error)
+}
+
+// Интерфейс хранилища акций
+type PromotionRepository interface {
+	// GetPromotions получает все акции
+	GetPromotions() ([]byte, error)
+
+	// SavePromotions сохраняет акции
+	SavePromotions([]byte) error
+
+	// UnmarshalJsonPromotions десериализует акции из JSON
+	UnmarshalJsonPromotions(data []byte) ([]*domain.Promotion, error)
+
+	// MarshalJsonPromotions сериализует акции в JSON
+	MarshalJsonPromotions(promotions []*domain.Promotion) ([]byte, error)
+
+	// UnmarshalJsonPromotion десериализует одну акцию из JSON
+	UnmarshalJsonPromotion(data []byte) (*domain.Promotion, error)
+
+	// MarshalJsonPromotion сериализует одну акцию в JSON
+	MarshalJsonPromotion(promotion *domain.Promotion) ([]byte, error)
+}
+
+// Интерфейс хранилища отчетов о закрытии дня
+type DailyReportRepository interface {
+	// GetDailyReports получает все отчеты о закрытии дня
+	GetDailyReports() ([]byte, error)
+
+	// SaveDailyReports сохраняет отчеты о закрытии дня
+	SaveDailyReports([]byte) error
+
+	// UnmarshalJsonDailyReports десериализует отчеты о закрытии дня из JSON
+	UnmarshalJsonDailyReports(data []byte) ([]*domain.DailyReport, error)
+
+	// MarshalJsonDailyReports сериализует отчеты о закрытии дня в JSON
+	MarshalJsonDailyReports(reports []*domain.DailyReport) ([]byte, error)
+}
+
 // Интерфейс агрегированных данных
 type AgreggationRepository interface {
-	GetTotalSales() (float64, error)
-	GetPopularItems() ([]domain.ProductSales, error)
+	// GetTotalSales вычисляет сумму продаж за период [from, to); нулевые границы не ограничивают период
+	GetTotalSales(from, to time.Time) (float64, error)
+
+	// GetSalesByPeriod разбивает сумму продаж за период [from, to) на интервалы в заданном часовом поясе
+	GetSalesByPeriod(from, to time.Time, groupBy domain.ReportGroupBy, loc *time.Location) ([]domain.SalesPeriod, error)
+
+	// GetPopularItems считает проданное количество каждой позиции за период [from, to)
+	GetPopularItems(from, to time.Time) ([]domain.ProductSales, error)
+
+	// GetBasketReport считает средний чек и совместные покупки всех пар позиций за период [from, to)
+	GetBasketReport(from, to time.Time) (*domain.BasketReport, error)
 }
@@ -1,6 +1,209 @@
 package domain
 
+import (
+	"fmt"
+	"time"
+)
+
 type ProductSales struct {
-	ProductID string `json:"product_id"`
-	Quantity  int    `json:"quantity"`
+	ProductID string  `json:"product_id"`
+	Name      string  `json:"name,omitempty"`
+	Quantity  int     `json:"quantity"`
+	Revenue   float64 `json:"revenue"`
+	// Продажи позиции в составе комбо; выручка комбо делится между компонентами пропорционально их ценам
+	BundleQuantity int     `json:"bundle_quantity,omitempty"`
+	BundleRevenue  float64 `json:"bundle_revenue,omitempty"`
+}
+
+// Показатель, по которому ранжируются позиции
+type PopularItemsSort string
+
+const (
+	SortByQuantity PopularItemsSort = "quantity"
+	SortByRevenue  PopularItemsSort = "revenue"
+)
+
+func (s PopularItemsSort) IsValid() bool {
+	return s == SortByQuantity || s == SortByRevenue
+}
+
+// Вид отчета: самые популярные, наименее популярные (включая непроданные) или только непроданные позиции
+type PopularItemsView string
+
+const (
+	ViewPopular PopularItemsView = "popular"
+	ViewLeast   PopularItemsView = "least"
+	ViewUnsold  PopularItemsView = "unsold"
+)
+
+func (v PopularItemsView) IsValid() bool {
+	return v == ViewPopular || v == ViewLeast || v == ViewUnsold
+}
+
+// Параметры отчета о популярных позициях; нулевой Limit не ограничивает результат
+type PopularItemsOptions struct {
+	Sort  PopularItemsSort
+	View  PopularItemsView
+	Limit int
+}
+
+// Шаг разбивки отчета по периодам
+type ReportGroupBy string
+
+const (
+	GroupByHour  ReportGroupBy = "hour"
+	GroupByDay   ReportGroupBy = "day"
+	GroupByWeek  ReportGroupBy = "week"
+	GroupByMonth ReportGroupBy = "month"
+)
+
+// Наибольшее количество интервалов в отчете с разбивкой
+const MaxReportPeriods = 1000
+
+// ErrTooManyPeriods возвращается, если период отчета не укладывается в MaxReportPeriods интервалов
+var ErrTooManyPeriods = fmt.Errorf("report range exceeds %d periods", MaxReportPeriods)
+
+func (g ReportGroupBy) IsValid() bool {
+	switch g {
+	case GroupByHour, GroupByDay, GroupByWeek, GroupByMonth:
+		return true
+	}
+	return false
+}
+
+// Truncate возвращает начало периода, в который попадает t; недели начинаются с понедельника
+func (g ReportGroupBy) Truncate(t time.Time, loc *time.Location) time.Time {
+	t = t.In(loc)
+	year, month, day := t.Date()
+	switch g {
+	case GroupByHour:
+		return time.Date(year, month, day, t.Hour(), 0, 0, 0, loc)
+	case GroupByWeek:
+		offset := (int(t.Weekday()) + 6) % 7
+		return time.Date(year, month, day-offset, 0, 0, 0, 0, loc)
+	case GroupByMonth:
+		return time.Date(year, month, 1, 0, 0, 0, 0, loc)
+	}
+	return time.Date(year, month, day, 0, 0, 0, 0, loc)
+}
+
+// Next возвращает начало следующего периода после start
+func (g ReportGroupBy) Next(start time.Time) time.Time {
+	switch g {
+	case GroupByHour:
+		return start.Add(time.Hour)
+	case GroupByWeek:
+		return start.AddDate(0, 0, 7)
+	case GroupByMonth:
+		return start.AddDate(0, 1, 0)
+	}
+	return start.AddDate(0, 0, 1)
+}
+
+// Periods возвращает количество интервалов в периоде [from, to), но не больше MaxReportPeriods+1
+func (g ReportGroupBy) Periods(from, to time.Time, loc *time.Location) int {
+	count := 0
+	for start := g.Truncate(from, loc); start.Before(to) && count <= MaxReportPeriods; start = g.Next(start) {
+		count++
+	}
+	return count
+}
+
+// Сравнение с предыдущим периодом
+type ReportCompare string
+
+const (
+	ComparePreviousPeriod ReportCompare = "previous_period"
+	ComparePreviousYear   ReportCompare = "previous_year"
+)
+
+func (c ReportCompare) IsValid() bool {
+	return c == ComparePreviousPeriod || c == ComparePreviousYear
+}
+
+// Параметры отчета: период [From, To), часовой пояс, разбивка и сравнение.
+// Нулевые границы не ограничивают период.
+type ReportFilter struct {
+	From     time.Time
+	To       time.Time
+	Location *time.Location
+	GroupBy  ReportGroupBy
+	Compare  ReportCompare
+}
+
+// Previous возвращает такой же по длине период перед [From, To) или тот же период год назад
+func (f ReportFilter) Previous() (time.Time, time.Time) {
+	if f.Compare == ComparePreviousYear {
+		return f.From.AddDate(-1, 0, 0), f.To.AddDate(-1, 0, 0)
+	}
+	return f.From.Add(-f.To.Sub(f.From)), f.From
+}
+
+// Продажи за один период разбивки
+type SalesPeriod struct {
+	PeriodStart time.Time `json:"period_start"`
+	TotalSales  float64   `json:"total_sales"`
+	Orders      int       `json:"orders"`
+}
+
+// Отчет о сумме продаж за период
+type SalesReport struct {
+	From       *time.Time `json:"start_date,omitempty"`
+	To         *time.Time `json:"end_date,omitempty"`
+	TotalSales float64    `json:"total_sales"`
+	// Скидки акций и наград и продажи за их вычетом
+	Discounts  float64          `json:"discounts"`
+	NetSales   float64          `json:"net_sales"`
+	Promotions []PromotionUsage `json:"promotions"`
+	GroupBy    ReportGroupBy    `json:"group_by,omitempty"`
+	Periods    []SalesPeriod    `json:"periods,omitempty"`
+	Comparison *SalesComparison `json:"comparison,omitempty"`
+
+	// Оплаты завершенных заказов по способам оплаты и чаевые
+	PaymentsByMethod map[string]float64 `json:"payments_by_method"`
+	Tips             float64            `json:"tips"`
+}
+
+// Сумма продаж за период сравнения и изменение относительно него
+type SalesComparison struct {
+	Compare    ReportCompare `json:"compare"`
+	From       time.Time     `json:"start_date"`
+	To         time.Time     `json:"end_date"`
+	TotalSales float64       `json:"total_sales"`
+	Change     float64       `json:"change"`
+	// Пусто, если в периоде сравнения продаж не было
+	ChangePercent *float64 `json:"change_percent,omitempty"`
+}
+
+// Анализ корзины за период: средний чек, размер заказа и совместные покупки
+type BasketReport struct {
+	From              *time.Time    `json:"start_date,omitempty"`
+	To                *time.Time    `json:"end_date,omitempty"`
+	Orders            int           `json:"orders"`
+	TotalSales        float64       `json:"total_sales"`
+	AverageOrderValue float64       `json:"average_order_value"`
+	AverageItems      float64       `json:"average_items"`
+	MinSupport        float64       `json:"min_support"`
+	Pairs             []ProductPair `json:"pairs"`
+}
+
+// Пара позиций, купленных в одном заказе.
+// Support — доля заказов с обеими позициями, ConfidenceAB — доля заказов с A, в которых есть и B,
+// Lift — во сколько раз пара встречается чаще, чем при независимых покупках.
+type ProductPair struct {
+	ProductA     string  `json:"product_a"`
+	NameA        string  `json:"name_a,omitempty"`
+	ProductB     string  `json:"product_b"`
+	NameB        string  `json:"name_b,omitempty"`
+	Orders       int     `json:"orders"`
+	Support      float64 `json:"support"`
+	ConfidenceAB float64 `json:"confidence_a_to_b"`
+	ConfidenceBA float64 `json:"confidence_b_to_a"`
+	Lift         float64 `json:"lift"`
+}
+
+// Параметры анализа корзины: минимальная доля заказов с парой и ограничение количества пар
+type BasketOptions struct {
+	MinSupport float64
+	Limit      int
 }
@@ -0,0 +1,74 @@
+package domain
+
+import "time"
+
+// Метод расчета себестоимости списаний. FIFO оценивает списания по ценам самых ранних поступлений
+// независимо от того, какие партии физически расходуются (FEFO).
+type CostingMethod string
+
+const (
+	CostingFIFO    CostingMethod = "fifo"
+	CostingAverage CostingMethod = "average"
+)
+
+func (m CostingMethod) IsValid() bool {
+	return m == CostingFIFO || m == CostingAverage
+}
+
+// Оценка запасов за период по данным журнала движений
+type InventoryValuation struct {
+	Method       CostingMethod         `json:"costing_method"`
+	From         *time.Time            `json:"start_date,omitempty"`
+	To           *time.Time            `json:"end_date,omitempty"`
+	OpeningValue float64               `json:"opening_value"`
+	ClosingValue float64               `json:"closing_value"`
+	Items        []IngredientValuation `json:"items"`
+}
+
+// Движение стоимости ингредиента за период: остаток на начало, поступления, расход и остаток на конец.
+// Возвраты на склад по возвращенным заказам указаны отдельно от продаж.
+type IngredientValuation struct {
+	IngredientID     string  `json:"ingredient_id"`
+	Name             string  `json:"name,omitempty"`
+	Unit             string  `json:"unit,omitempty"`
+	OpeningQuantity  float64 `json:"opening_quantity"`
+	OpeningValue     float64 `json:"opening_value"`
+	ReceivedQuantity float64 `json:"received_quantity"`
+	ReceivedValue    float64 `json:"received_value"`
+	SoldQuantity     float64 `json:"sold_quantity"`
+	COGS             float64 `json:"cogs"`
+	ReturnedQuantity float64 `json:"returned_quantity"`
+	ReturnedValue    float64 `json:"returned_value"`
+	WastedQuantity   float64 `json:"wasted_quantity"`
+	WasteValue       float64 `json:"waste_value"`
+	AdjustmentValue  float64 `json:"adjustment_value"`
+	ClosingQuantity  float64 `json:"closing_quantity"`
+	ClosingValue     float64 `json:"closing_value"`
+	UnitCost         float64 `json:"unit_cost"`
+}
+
+// Себестоимость продаж за период за вычетом себестоимости ингредиентов, возвращенных на склад с заказами
+type COGSReport struct {
+	Method CostingMethod `json:"costing_method"`
+	From   *time.Time    `json:"start_date,omitempty"`
+	To     *time.Time    `json:"end_date,omitempty"`
+	Total  float64       `json:"total_cogs"`
+	// Себестоимость возвращенных на склад ингредиентов, уже вычтенная из Total
+	Returned     float64          `json:"returned_cogs"`
+	ByIngredient []IngredientCOGS `json:"by_ingredient"`
+	ByProduct    []ProductCOGS    `json:"by_product"`
+}
+
+type IngredientCOGS struct {
+	IngredientID string  `json:"ingredient_id"`
+	Name         string  `json:"name,omitempty"`
+	Unit         string  `json:"unit,omitempty"`
+	Quantity     float64 `json:"quantity"`
+	Cost         float64 `json:"cost"`
+}
+
+type ProductCOGS struct {
+	ProductID string  `json:"product_id"`
+	Name      string  `json:"name,omitempty"`
+	Cost      float64 `json:"cost"`
+}
@@ -0,0 +1,30 @@
+package domain
+
+import "time"
+
+// Постоянный клиент. Заказы ссылаются на него по customer_id; заказы без клиента оформляются только по имени.
+type Customer struct {
+	ID    string `json:"customer_id"`
+	Name  string `json:"name"`
+	Phone string `json:"phone,omitempty"`
+	Email string `json:"email,omitempty"`
+	Notes string `json:"notes,omitempty"`
+	// Предпочтения клиента, например "oat milk"
+	Preferences []string  `json:"preferences,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// Клиент со статистикой его завершенных заказов
+type CustomerProfile struct {
+	Customer
+	Stats CustomerStats `json:"stats"`
+}
+
+type CustomerStats struct {
+	Visits            int            `json:"visits"`
+	TotalSpent        float64        `json:"total_spent"`
+	AverageOrderValue float64        `json:"average_order_value"`
+	FirstVisit        *time.Time     `json:"first_visit,omitempty"`
+	LastVisit         *time.Time     `json:"last_visit,omitempty"`
+	FavoriteItems     []ProductSales `json:"favorite_items"`
+}
@@ -0,0 +1,33 @@
+package domain
+
+import "time"
+
+// Итоги закрытого рабочего дня (Z-отчет). После закрытия отчет не изменяется.
+type DailyReport struct {
+	Date     string     `json:"date"`
+	Closed   bool       `json:"closed"`
+	ClosedAt *time.Time `json:"closed_at,omitempty"`
+	ClosedBy string     `json:"closed_by,omitempty"`
+
+	// Заказы дня входят в продажи дня, даже если позже были возвращены
+	Orders        int `json:"orders"`
+	PendingOrders int `json:"pending_orders"`
+	Cancellations int `json:"cancellations"`
+	// Возвраты, оформленные в этот день, в том числе по заказам прошлых дней, и их сумма за вычетом скидок
+	Refunds  int     `json:"refunds"`
+	Refunded float64 `json:"refunded"`
+
+	// Цены меню включают налог; чистая выручка — за вычетом скидок, возвратов и налога
+	GrossSales float64 `json:"gross_sales"`
+	Discounts  float64 `json:"discounts"`
+	TaxRate    float64 `json:"tax_rate"`
+	Taxes      float64 `json:"taxes"`
+	NetSales   float64 `json:"net_sales"`
+
+	// Оплаты заказов дня и встречные оплаты возвратов дня по способам оплаты; чаевые не входят в выручку
+	PaymentsByMethod map[string]float64 `json:"payments_by_method"`
+	Tips             float64            `json:"tips"`
+	TopItems         []ProductSales     `json:"top_items"`
+	Promotions       []PromotionUsage   `json:"promotions"`
+	IngredientUsage  []IngredientCOGS   `json:"ingredient_usage"`
+}
@@ -0,0 +1,28 @@
+package domain
+
+import "time"
+
+// Прогноз расхода ингредиентов по истории продаж
+type Forecast struct {
+	GeneratedAt  time.Time            `json:"generated_at"`
+	LookbackDays int                  `json:"lookback_days"`
+	HorizonDays  int                  `json:"horizon_days"`
+	Items        []IngredientForecast `json:"items"`
+}
+
+// Прогноз по ингредиенту: средний расход по дням недели, дата исчерпания остатка и рекомендуемый заказ
+type IngredientForecast struct {
+	IngredientID      string             `json:"ingredient_id"`
+	Name              string             `json:"name"`
+	Unit              string             `json:"unit"`
+	Quantity          float64            `json:"quantity"`
+	OnOrder           float64            `json:"on_order"`
+	AverageDailyUsage float64            `json:"average_daily_usage"`
+	WeekdayUsage      map[string]float64 `json:"weekday_usage"`
+	ProjectedUsage    float64            `json:"projected_usage"`
+	// Пусто, если по истории продаж остаток не закончится в пределах прогноза
+	DaysUntilStockout *float64   `json:"days_until_stockout,omitempty"`
+	StockoutAt        *time.Time `json:"stockout_at,omitempty"`
+	LeadTimeDays      int        `json:"lead_time_days"`
+	SuggestedQuantity float64    `json:"suggested_quantity"`
+}
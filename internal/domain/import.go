@@ -0,0 +1,47 @@
+package domain
+
+// Режим импорта: только добавление новых записей или добавление с обновлением существующих
+type ImportMode string
+
+const (
+	ImportInsert ImportMode = "insert"
+	ImportUpsert ImportMode = "upsert"
+)
+
+func (m ImportMode) IsValid() bool {
+	return m == ImportInsert || m == ImportUpsert
+}
+
+// Параметры импорта; при DryRun записи только проверяются
+type ImportOptions struct {
+	Mode   ImportMode
+	DryRun bool
+}
+
+// Действие импорта над записью
+type ImportAction string
+
+const (
+	ImportCreate ImportAction = "create"
+	ImportUpdate ImportAction = "update"
+)
+
+// Результат проверки одной записи импорта; строки нумеруются с единицы без учета заголовка CSV
+type ImportRowResult struct {
+	Row    int          `json:"row"`
+	ID     string       `json:"id,omitempty"`
+	Action ImportAction `json:"action,omitempty"`
+	Errors []string     `json:"errors,omitempty"`
+}
+
+// Итоги импорта. Записи применяются, только если ни в одной строке нет ошибок.
+type ImportResult struct {
+	Mode    ImportMode        `json:"mode"`
+	DryRun  bool              `json:"dry_run"`
+	Applied bool              `json:"applied"`
+	Total   int               `json:"total"`
+	Created int               `json:"created"`
+	Updated int               `json:"updated"`
+	Failed  int               `json:"failed"`
+	Rows    []ImportRowResult `json:"rows"`
+}
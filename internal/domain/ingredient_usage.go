@@ -0,0 +1,40 @@
+package domain
+
+import "time"
+
+// Отчет о расходе ингредиентов за период: расход по рецептам проданных позиций в сравнении с журналом движений
+type IngredientUsageReport struct {
+	From *time.Time `json:"start_date,omitempty"`
+	To   *time.Time `json:"end_date,omitempty"`
+	// Порог необъяснимого расхождения в процентах, выше которого ингредиент отмечается
+	VarianceThreshold float64           `json:"variance_threshold"`
+	Items             []IngredientUsage `json:"items"`
+}
+
+// Расход ингредиента в единицах инвентаря.
+// Expected — расход по рецептам завершенных заказов; Actual — списания по журналу (продажи за вычетом возвратов, отходы и корректировки).
+// Необъяснимое расхождение — фактический расход за вычетом ожидаемого и учтенных отходов.
+type IngredientUsage struct {
+	IngredientID     string  `json:"ingredient_id"`
+	Name             string  `json:"name,omitempty"`
+	Unit             string  `json:"unit,omitempty"`
+	ExpectedQuantity float64 `json:"expected_quantity"`
+	SoldQuantity     float64 `json:"sold_quantity"`
+	ReturnedQuantity float64 `json:"returned_quantity"`
+	WastedQuantity   float64 `json:"wasted_quantity"`
+	AdjustedQuantity float64 `json:"adjusted_quantity"`
+	ActualQuantity   float64 `json:"actual_quantity"`
+	Variance         float64 `json:"unexplained_variance"`
+	// Пусто, если ожидаемого расхода не было
+	VariancePercent *float64                 `json:"variance_percent,omitempty"`
+	Flagged         bool                     `json:"flagged"`
+	ByProduct       []ProductIngredientUsage `json:"by_product"`
+}
+
+// Расход ингредиента на позицию меню
+type ProductIngredientUsage struct {
+	ProductID string  `json:"product_id"`
+	Name      string  `json:"name,omitempty"`
+	ItemsSold int     `json:"items_sold"`
+	Quantity  float64 `json:"quantity"`
+}
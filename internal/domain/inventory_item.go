@@ -1,8 +1,49 @@
 package domain
 
+import "time"
+
 type InventoryItem struct {
 	IngredientID string  `json:"ingredient_id"`
 	Name         string  `json:"name"`
 	Quantity     float64 `json:"quantity"`
 	Unit         string  `json:"unit"`
+	// Точка заказа: при остатке на этом уровне или ниже ингредиент считается заканчивающимся
+	ReorderPoint float64 `json:"reorder_point,omitempty"`
+	// Нормативный запас, до которого нужно пополнить ингредиент
+	ParLevel float64 `json:"par_level,omitempty"`
+}
+
+// IsLowStock сообщает, опустился ли остаток до точки заказа
+func (i *InventoryItem) IsLowStock() bool {
+	return i.ReorderPoint > 0 && i.Quantity <= i.ReorderPoint
+}
+
+// Ингредиент с низким остатком и рекомендуемым объемом пополнения
+type LowStockItem struct {
+	IngredientID      string    `json:"ingredient_id"`
+	Name              string    `json:"name"`
+	Quantity          float64   `json:"quantity"`
+	Unit              string    `json:"unit"`
+	ReorderPoint      float64   `json:"reorder_point"`
+	ParLevel          float64   `json:"par_level"`
+	SuggestedQuantity float64   `json:"suggested_quantity"`
+	DetectedAt        time.Time `json:"detected_at"`
+}
+
+// NewLowStockItem формирует запись о низком остатке для ингредиента
+func NewLowStockItem(item *InventoryItem, now time.Time) LowStockItem {
+	suggested := 0.0
+	if item.ParLevel > item.Quantity {
+		suggested = item.ParLevel - item.Quantity
+	}
+	return LowStockItem{
+		IngredientID:      item.IngredientID,
+		Name:              item.Name,
+		Quantity:          item.Quantity,
+		Unit:              item.Unit,
+		ReorderPoint:      item.ReorderPoint,
+		ParLevel:          item.ParLevel,
+		SuggestedQuantity: suggested,
+		DetectedAt:        now,
+	}
 }
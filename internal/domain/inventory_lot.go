@@ -0,0 +1,33 @@
+package domain
+
+import "time"
+
+// Партия ингредиента: поступление с датой приемки и сроком годности
+type InventoryLot struct {
+	ID           string     `json:"lot_id"`
+	IngredientID string     `json:"ingredient_id"`
+	Quantity     float64    `json:"quantity"`
+	ReceivedAt   time.Time  `json:"received_at"`
+	ExpiresAt    *time.Time `json:"expires_at,omitempty"`
+	// Себестоимость единицы партии в единицах измерения инвентаря
+	UnitCost float64 `json:"unit_cost,omitempty"`
+}
+
+// IsExpired сообщает, истек ли срок годности партии к моменту now
+func (l *InventoryLot) IsExpired(now time.Time) bool {
+	return l.ExpiresAt != nil && !l.ExpiresAt.After(now)
+}
+
+// ConsumesBefore определяет порядок списания FEFO: сначала партии с ближайшим сроком годности,
+// партии без срока годности в конце, при равенстве — более ранние поступления
+func (l *InventoryLot) ConsumesBefore(other *InventoryLot) bool {
+	switch {
+	case l.ExpiresAt != nil && other.ExpiresAt == nil:
+		return true
+	case l.ExpiresAt == nil && other.ExpiresAt != nil:
+		return false
+	case l.ExpiresAt != nil && !l.ExpiresAt.Equal(*other.ExpiresAt):
+		return l.ExpiresAt.Before(*other.ExpiresAt)
+	}
+	return l.ReceivedAt.Before(other.ReceivedAt)
+}
@@ -0,0 +1,123 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// Параметры сортировки и постраничного вывода списков.
+// Пустой Sort сохраняет порядок хранения, нулевой PerPage выводит все записи.
+type ListOptions struct {
+	Sort    string
+	Desc    bool
+	Page    int
+	PerPage int
+}
+
+// Сведения о странице списка
+type PageInfo struct {
+	Page    int
+	PerPage int
+	Total   int
+}
+
+// Pages возвращает количество страниц
+func (p PageInfo) Pages() int {
+	if p.PerPage <= 0 {
+		return 1
+	}
+	return max(1, (p.Total+p.PerPage-1)/p.PerPage)
+}
+
+// Фильтр списка заказов; пустые поля не ограничивают выборку
+type OrderFilter struct {
+	Status       OrderStatus
+	CustomerName string
+	CustomerID   string
+	ProductID    string
+	From         time.Time
+	To           time.Time
+}
+
+// Matches проверяет, подходит ли заказ под фильтр. Имя клиента ищется без учета регистра.
+func (f OrderFilter) Matches(order *Order) bool {
+	if f.Status != "" && order.Status != f.Status {
+		return false
+	}
+	if f.CustomerName != "" && !containsFold(order.CustomerName, f.CustomerName) {
+		return false
+	}
+	if f.CustomerID != "" && order.CustomerID != f.CustomerID {
+		return false
+	}
+	if !f.From.IsZero() && order.CreatedAt.Before(f.From) {
+		return false
+	}
+	if !f.To.IsZero() && !order.CreatedAt.Before(f.To) {
+		return false
+	}
+	if f.ProductID != "" {
+		for _, item := range order.Items {
+			if item.ProductID == f.ProductID {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// Фильтр меню; нулевые границы цены не ограничивают выборку
+type MenuFilter struct {
+	Search       string
+	IngredientID string
+	MinPrice     float64
+	MaxPrice     float64
+}
+
+// Matches проверяет, подходит ли позиция меню под фильтр. Search ищется в названии и описании.
+func (f MenuFilter) Matches(item *MenuItem) bool {
+	if f.Search != "" && !containsFold(item.Name, f.Search) && !containsFold(item.Description, f.Search) {
+		return false
+	}
+	if f.MinPrice > 0 && item.Price < f.MinPrice {
+		return false
+	}
+	if f.MaxPrice > 0 && item.Price > f.MaxPrice {
+		return false
+	}
+	if f.IngredientID != "" {
+		for _, ingredient := range item.Ingredients {
+			if ingredient.IngredientID == f.IngredientID {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// Фильтр инвентаря
+type InventoryFilter struct {
+	Search   string
+	Unit     string
+	LowStock bool
+}
+
+// Matches проверяет, подходит ли ингредиент под фильтр
+func (f InventoryFilter) Matches(item *InventoryItem) bool {
+	if f.Search != "" && !containsFold(item.Name, f.Search) && !containsFold(item.IngredientID, f.Search) {
+		return false
+	}
+	if f.Unit != "" && item.Unit != f.Unit {
+		return false
+	}
+	if f.LowStock && !item.IsLowStock() {
+		return false
+	}
+	return true
+}
+
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
@@ -0,0 +1,93 @@
+package domain
+
+import "time"
+
+type EarnRuleType string
+
+const (
+	// Баллы за каждую денежную единицу оплаченной суммы заказа
+	EarnPerAmount EarnRuleType = "per_amount"
+	// Баллы за каждую штуку подходящих позиций заказа
+	EarnPerItem EarnRuleType = "per_item"
+)
+
+func (t EarnRuleType) IsValid() bool {
+	return t == EarnPerAmount || t == EarnPerItem
+}
+
+// Правило начисления баллов за завершенный заказ. Баллы всех правил складываются и округляются вниз.
+type EarnRule struct {
+	ID     string       `json:"rule_id"`
+	Type   EarnRuleType `json:"type"`
+	Points float64      `json:"points"`
+	// Позиции меню, за которые начисляются баллы per_item; пусто — все позиции
+	ProductIDs []string `json:"product_ids,omitempty"`
+}
+
+type RewardType string
+
+const (
+	// Бесплатная единица позиции меню из заказа
+	RewardFreeItem RewardType = "free_item"
+	// Скидка на фиксированную сумму
+	RewardDiscount RewardType = "discount"
+)
+
+func (t RewardType) IsValid() bool {
+	return t == RewardFreeItem || t == RewardDiscount
+}
+
+// Награда, которую клиент получает за баллы при оформлении заказа
+type Reward struct {
+	ID     string     `json:"reward_id"`
+	Name   string     `json:"name"`
+	Type   RewardType `json:"type"`
+	Points int        `json:"points"`
+	// Бесплатная позиция для наград free_item
+	ProductID string `json:"product_id,omitempty"`
+	// Сумма скидки для наград discount
+	Amount float64 `json:"amount,omitempty"`
+}
+
+// Награда, примененная к заказу. В запросе достаточно reward_id, остальное заполняется при оформлении.
+type RewardRedemption struct {
+	RewardID string  `json:"reward_id"`
+	Name     string  `json:"name"`
+	Points   int     `json:"points"`
+	Discount float64 `json:"discount"`
+}
+
+type LoyaltyEntryType string
+
+const (
+	LoyaltyEarn       LoyaltyEntryType = "earn"
+	LoyaltyRedeem     LoyaltyEntryType = "redeem"
+	LoyaltyReversal   LoyaltyEntryType = "reversal"
+	LoyaltyAdjustment LoyaltyEntryType = "adjustment"
+)
+
+// Запись журнала баллов клиента; баланс — сумма баллов всех записей
+type LoyaltyEntry struct {
+	ID         string           `json:"entry_id"`
+	CustomerID string           `json:"customer_id"`
+	Type       LoyaltyEntryType `json:"type"`
+	Points     int              `json:"points"`
+	OrderID    string           `json:"order_id,omitempty"`
+	RewardID   string           `json:"reward_id,omitempty"`
+	Reason     string           `json:"reason,omitempty"`
+	User       string           `json:"user,omitempty"`
+	CreatedAt  time.Time        `json:"created_at"`
+}
+
+// Баланс и журнал баллов клиента
+type LoyaltyAccount struct {
+	CustomerID string         `json:"customer_id"`
+	Balance    int            `json:"balance"`
+	Entries    []LoyaltyEntry `json:"entries"`
+}
+
+// Ручное начисление или списание баллов, например при переносе бумажной карты
+type PointsAdjustment struct {
+	Points int    `json:"points"`
+	Reason string `json:"reason"`
+}
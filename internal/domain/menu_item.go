@@ -1,14 +1,118 @@
 package domain
 
+import (
+	"fmt"
+	"strings"
+)
+
 type MenuItem struct {
-	ID          string               `json:"product_id"`
-	Name        string               `json:"name"`
-	Description string               `json:"description"`
-	Price       float64              `json:"price"`
+	ID          string  `json:"product_id"`
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	Price       float64 `json:"price"`
+	// Категория для акций на группу позиций, например "pastry"
+	Category    string               `json:"category,omitempty"`
 	Ingredients []MenuItemIngredient `json:"ingredients"`
+	// Состав комбо; у обычной позиции пусто. Собственные ингредиенты комбо (например, упаковка) добавляются к рецептам компонентов.
+	Components []BundleComponent `json:"components,omitempty"`
 }
 
 type MenuItemIngredient struct {
 	IngredientID string  `json:"ingredient_id"`
 	Quantity     float64 `json:"quantity"`
+	// Единица измерения в рецепте; если не указана, используется единица инвентаря
+	Unit string `json:"unit,omitempty"`
+}
+
+// Компонент комбо: конкретная позиция меню или слот выбора любой позиции категории
+type BundleComponent struct {
+	ProductID string `json:"product_id,omitempty"`
+	Category  string `json:"category,omitempty"`
+	Quantity  int    `json:"quantity"`
+}
+
+// IsChoice проверяет, является ли компонент слотом выбора
+func (c BundleComponent) IsChoice() bool {
+	return c.ProductID == "" && c.Category != ""
+}
+
+// Позиция меню в составе одной штуки комбо
+type BundleLine struct {
+	ProductID string
+	Quantity  int
+}
+
+// IsBundle проверяет, является ли позиция комбо
+func (m *MenuItem) IsBundle() bool {
+	return len(m.Components) > 0
+}
+
+// ChoiceSlots возвращает количество слотов выбора комбо
+func (m *MenuItem) ChoiceSlots() int {
+	slots := 0
+	for _, component := range m.Components {
+		if component.IsChoice() {
+			slots++
+		}
+	}
+	return slots
+}
+
+// BundleLines раскрывает одну штуку комбо в позиции компонентов.
+// choices по порядку заполняют слоты выбора; выбранная позиция должна быть из категории слота и не быть комбо.
+func (m *MenuItem) BundleLines(choices []string, lookup func(id string) *MenuItem) ([]BundleLine, error) {
+	if slots := m.ChoiceSlots(); len(choices) != slots {
+		return nil, fmt.Errorf("bundle %s needs %d choices, got %d", m.ID, slots, len(choices))
+	}
+
+	lines := make([]BundleLine, 0, len(m.Components))
+	next := 0
+	for _, component := range m.Components {
+		if !component.IsChoice() {
+			lines = append(lines, BundleLine{ProductID: component.ProductID, Quantity: component.Quantity})
+			continue
+		}
+
+		choice := choices[next]
+		next++
+		item := lookup(choice)
+		if item == nil {
+			return nil, fmt.Errorf("menu item %s chosen for bundle %s not found", choice, m.ID)
+		}
+		if item.IsBundle() {
+			return nil, fmt.Errorf("bundle %s can't be chosen for bundle %s", choice, m.ID)
+		}
+		if !strings.EqualFold(item.Category, component.Category) {
+			return nil, fmt.Errorf("menu item %s is not in category %s required by bundle %s", choice, component.Category, m.ID)
+		}
+		lines = append(lines, BundleLine{ProductID: choice, Quantity: component.Quantity})
+	}
+	return lines, nil
+}
+
+// BundleRevenue распределяет цену price, по которой продано комбо, между его компонентами пропорционально их обычным ценам.
+// Если цены компонентов неизвестны, цена делится пропорционально количеству.
+func (m *MenuItem) BundleRevenue(price float64, lines []BundleLine, lookup func(id string) *MenuItem) []float64 {
+	weights := make([]float64, len(lines))
+	var total float64
+	for i, line := range lines {
+		if item := lookup(line.ProductID); item != nil {
+			weights[i] = item.Price * float64(line.Quantity)
+			total += weights[i]
+		}
+	}
+	if total == 0 {
+		for i, line := range lines {
+			weights[i] = float64(line.Quantity)
+			total += weights[i]
+		}
+	}
+
+	revenue := make([]float64, len(lines))
+	for i := range lines {
+		if total > 0 {
+			revenue[i] = price * weights[i] / total
+		}
+	}
+	return revenue
 }
@@ -7,17 +7,82 @@ type OrderStatus string
 const (
 	StatusPending   OrderStatus = "pending"
 	StatusCompleted OrderStatus = "completed"
+	StatusCancelled OrderStatus = "cancelled"
+	StatusRefunded  OrderStatus = "refunded"
 )
 
+func (s OrderStatus) IsValid() bool {
+	return s == StatusPending || s == StatusCompleted || s == StatusCancelled || s == StatusRefunded
+}
+
 type Order struct {
-	ID           string      `json:"order_id"`
-	CustomerName string      `json:"customer_name"`
-	Items        []OrderItem `json:"items"`
-	Status       OrderStatus `json:"status"`
-	CreatedAt    time.Time   `json:"created_at"`
+	ID           string `json:"order_id"`
+	CustomerName string `json:"customer_name"`
+	// Клиент заказа; пусто у заказов без профиля клиента
+	CustomerID string      `json:"customer_id,omitempty"`
+	Items      []OrderItem `json:"items"`
+	Status     OrderStatus `json:"status"`
+	CreatedAt  time.Time   `json:"created_at"`
+	// Время закрытия заказа; пусто у незавершенных заказов
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	// Награда программы лояльности, оплаченная баллами клиента
+	Reward *RewardRedemption `json:"reward,omitempty"`
+	// Купон, указанный при оформлении, и примененные акции
+	CouponCode string             `json:"coupon_code,omitempty"`
+	Promotions []AppliedPromotion `json:"promotions,omitempty"`
+	// Оплаты заказа; заказ можно разделить между несколькими способами оплаты
+	Payments []Payment `json:"payments,omitempty"`
+	// Возврат завершенного заказа
+	Refund *OrderRefund `json:"refund,omitempty"`
+}
+
+// Возврат завершенного заказа. Оплаты возвращаются тем же способом, баллы клиента отменяются.
+// При Restock ингредиенты возвращаются на склад, иначе остаются израсходованными.
+type OrderRefund struct {
+	Reason     string    `json:"reason"`
+	Restock    bool      `json:"restock"`
+	User       string    `json:"user,omitempty"`
+	RefundedAt time.Time `json:"refunded_at"`
 }
 
 type OrderItem struct {
 	ProductID string `json:"product_id"`
 	Quantity  int    `json:"quantity"`
+	// Позиции, выбранные для слотов комбо, по порядку слотов
+	Choices []string `json:"choices,omitempty"`
+	// Цена единицы на момент оценки заказа, в том числе нулевая. В заказах, оформленных до фиксации цен, не заполнена
+	Price *float64 `json:"price,omitempty"`
+}
+
+// UnitPrice возвращает зафиксированную цену единицы позиции, а без нее — текущую цену меню из lookup.
+// Второе значение ложно, если цена неизвестна.
+func (i OrderItem) UnitPrice(lookup func(id string) *MenuItem) (float64, bool) {
+	if i.Price != nil {
+		return *i.Price, true
+	}
+	if menuItem := lookup(i.ProductID); menuItem != nil {
+		return menuItem.Price, true
+	}
+	return 0, false
+}
+
+// Paid возвращает сумму оплат заказа без чаевых
+func (o *Order) Paid() float64 {
+	var paid float64
+	for _, payment := range o.Payments {
+		paid += payment.Amount
+	}
+	return paid
+}
+
+// Discount возвращает сумму скидок заказа по акциям и награде
+func (o *Order) Discount() float64 {
+	var discount float64
+	for _, promotion := range o.Promotions {
+		discount += promotion.Discount
+	}
+	if o.Reward != nil {
+		discount += o.Reward.Discount
+	}
+	return discount
 }
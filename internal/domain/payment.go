@@ -0,0 +1,49 @@
+package domain
+
+import "time"
+
+type PaymentMethod string
+
+const (
+	PaymentCash     PaymentMethod = "cash"
+	PaymentCard     PaymentMethod = "card"
+	PaymentGiftCard PaymentMethod = "gift_card"
+	PaymentOther    PaymentMethod = "other"
+)
+
+func (m PaymentMethod) IsValid() bool {
+	switch m {
+	case PaymentCash, PaymentCard, PaymentGiftCard, PaymentOther:
+		return true
+	}
+	return false
+}
+
+// Оплата заказа одним способом. Amount идет в счет заказа, чаевые учитываются отдельно.
+// Для наличных Tendered — полученная сумма, Change — выданная сдача.
+// Возврат записывается встречной оплатой с отрицательными суммами и ссылкой RefundOf на исходную оплату.
+type Payment struct {
+	ID        string        `json:"payment_id"`
+	Method    PaymentMethod `json:"method"`
+	Amount    float64       `json:"amount"`
+	Tip       float64       `json:"tip,omitempty"`
+	Tendered  float64       `json:"tendered,omitempty"`
+	Change    float64       `json:"change,omitempty"`
+	Reference string        `json:"reference,omitempty"`
+	RefundOf  string        `json:"refund_of,omitempty"`
+	User      string        `json:"user,omitempty"`
+	CreatedAt time.Time     `json:"created_at"`
+}
+
+// Расчет по заказу: сумма к оплате по зафиксированным в заказе ценам за вычетом скидок, оплачено и остаток.
+// Для старых заказов без зафиксированных цен берутся цены меню.
+type OrderPayments struct {
+	OrderID  string    `json:"order_id"`
+	Subtotal float64   `json:"subtotal"`
+	Discount float64   `json:"discount"`
+	Total    float64   `json:"total"`
+	Paid     float64   `json:"paid"`
+	Balance  float64   `json:"balance"`
+	Tips     float64   `json:"tips"`
+	Payments []Payment `json:"payments"`
+}
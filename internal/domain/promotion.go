@@ -0,0 +1,108 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+type PromotionType string
+
+const (
+	// Скидка в процентах от стоимости подходящих позиций
+	PromotionPercentage PromotionType = "percentage"
+	// Скидка на фиксированную сумму с подходящих позиций
+	PromotionFixed PromotionType = "fixed"
+	// За каждые BuyQuantity подходящих единиц следующие GetQuantity бесплатно; бесплатными считаются самые дешевые
+	PromotionBuyXGetY PromotionType = "buy_x_get_y"
+)
+
+func (t PromotionType) IsValid() bool {
+	return t == PromotionPercentage || t == PromotionFixed || t == PromotionBuyXGetY
+}
+
+// Акция, которая применяется к заказу при оформлении.
+// Без позиций и категории акция действует на весь заказ; акция с кодом купона — только на заказы с этим купоном.
+type Promotion struct {
+	ID   string        `json:"promotion_id"`
+	Name string        `json:"name"`
+	Type PromotionType `json:"type"`
+	// Процент скидки для percentage или сумма скидки для fixed
+	Value       float64  `json:"value,omitempty"`
+	BuyQuantity int      `json:"buy_quantity,omitempty"`
+	GetQuantity int      `json:"get_quantity,omitempty"`
+	ProductIDs  []string `json:"product_ids,omitempty"`
+	Category    string   `json:"category,omitempty"`
+	CouponCode  string   `json:"coupon_code,omitempty"`
+	// Дни недели (monday, ...) и часы [StartHour, EndHour) по местному времени; окно может переходить через полночь.
+	// Пустые дни и нулевые часы не ограничивают время действия.
+	Weekdays  []string   `json:"weekdays,omitempty"`
+	StartHour int        `json:"start_hour,omitempty"`
+	EndHour   int        `json:"end_hour,omitempty"`
+	StartsAt  *time.Time `json:"starts_at,omitempty"`
+	EndsAt    *time.Time `json:"ends_at,omitempty"`
+	// Наибольшее число неотмененных заказов с акцией; 0 — без ограничения
+	UsageLimit int `json:"usage_limit,omitempty"`
+}
+
+// ActiveAt проверяет, действует ли акция в момент t
+func (p *Promotion) ActiveAt(t time.Time) bool {
+	if p.StartsAt != nil && t.Before(*p.StartsAt) {
+		return false
+	}
+	if p.EndsAt != nil && !t.Before(*p.EndsAt) {
+		return false
+	}
+
+	local := t.In(time.Local)
+	if len(p.Weekdays) > 0 {
+		weekday := strings.ToLower(local.Weekday().String())
+		found := false
+		for _, day := range p.Weekdays {
+			if strings.ToLower(day) == weekday {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if p.StartHour == p.EndHour {
+		return true
+	}
+	hour := local.Hour()
+	if p.StartHour < p.EndHour {
+		return hour >= p.StartHour && hour < p.EndHour
+	}
+	return hour >= p.StartHour || hour < p.EndHour
+}
+
+// Covers проверяет, действует ли акция на позицию меню
+func (p *Promotion) Covers(item *MenuItem) bool {
+	if len(p.ProductIDs) == 0 && p.Category == "" {
+		return true
+	}
+	for _, id := range p.ProductIDs {
+		if id == item.ID {
+			return true
+		}
+	}
+	return p.Category != "" && strings.EqualFold(p.Category, item.Category)
+}
+
+// Акция, примененная к заказу
+type AppliedPromotion struct {
+	PromotionID string  `json:"promotion_id"`
+	Name        string  `json:"name"`
+	CouponCode  string  `json:"coupon_code,omitempty"`
+	Discount    float64 `json:"discount"`
+}
+
+// Итоги применения акции в отчетах
+type PromotionUsage struct {
+	PromotionID string  `json:"promotion_id"`
+	Name        string  `json:"name"`
+	Orders      int     `json:"orders"`
+	Discount    float64 `json:"discount"`
+}
@@ -0,0 +1,10 @@
+package domain
+
+// Результаты поиска, сгруппированные по типу
+type SearchResults struct {
+	Query     string           `json:"query"`
+	Total     int              `json:"total"`
+	Menu      []*MenuItem      `json:"menu"`
+	Orders    []*Order         `json:"orders"`
+	Inventory []*InventoryItem `json:"inventory"`
+}
@@ -0,0 +1,62 @@
+package domain
+
+import "time"
+
+type StockCountStatus string
+
+const (
+	StockCountOpen      StockCountStatus = "open"
+	StockCountCommitted StockCountStatus = "committed"
+	StockCountCancelled StockCountStatus = "cancelled"
+)
+
+// Сессия инвентаризации: фактические остатки, посчитанные на складе
+type StockCount struct {
+	ID          string           `json:"stock_count_id"`
+	Status      StockCountStatus `json:"status"`
+	Note        string           `json:"note,omitempty"`
+	OpenedBy    string           `json:"opened_by,omitempty"`
+	CreatedAt   time.Time        `json:"created_at"`
+	CommittedAt *time.Time       `json:"committed_at,omitempty"`
+	CancelledAt *time.Time       `json:"cancelled_at,omitempty"`
+	CancelledBy string           `json:"cancelled_by,omitempty"`
+	Lines       []StockCountLine `json:"lines"`
+}
+
+// Посчитанное количество ингредиента в единицах измерения инвентаря.
+// Theoretical — учетный остаток в момент подсчета; движения после подсчета сохраняются при проведении.
+// В строках, посчитанных до его записи, не заполнен.
+type StockCountLine struct {
+	IngredientID string    `json:"ingredient_id"`
+	Quantity     float64   `json:"quantity"`
+	Theoretical  *float64  `json:"theoretical,omitempty"`
+	Unit         string    `json:"unit,omitempty"`
+	CountedBy    string    `json:"counted_by,omitempty"`
+	CountedAt    time.Time `json:"counted_at"`
+}
+
+// Данные, отправляемые при подсчете; можно отправлять частями
+type StockCountSubmission struct {
+	Items []StockReceiptItem `json:"items"`
+}
+
+// Расхождение между учетным и фактическим остатком
+type StockVariance struct {
+	IngredientID string  `json:"ingredient_id"`
+	Name         string  `json:"name"`
+	Unit         string  `json:"unit"`
+	Theoretical  float64 `json:"theoretical"`
+	Counted      float64 `json:"counted"`
+	Variance     float64 `json:"variance"`
+	UnitCost     float64 `json:"unit_cost"`
+	CostImpact   float64 `json:"cost_impact"`
+}
+
+// Отчет о расхождениях по сессии инвентаризации
+type StockCountReport struct {
+	StockCountID    string           `json:"stock_count_id"`
+	Status          StockCountStatus `json:"status"`
+	Lines           []StockVariance  `json:"lines"`
+	Uncounted       []string         `json:"uncounted"`
+	TotalCostImpact float64          `json:"total_cost_impact"`
+}
@@ -0,0 +1,82 @@
+package domain
+
+import "time"
+
+type MovementType string
+
+const (
+	MovementSale       MovementType = "sale"
+	MovementRestock    MovementType = "restock"
+	MovementAdjustment MovementType = "adjustment"
+	MovementWaste      MovementType = "waste"
+	MovementTransfer   MovementType = "transfer"
+	// Возврат на склад ингредиентов проданного заказа при его возврате
+	MovementReturn MovementType = "return"
+)
+
+// Движение по складу: любое изменение остатка ингредиента
+type StockMovement struct {
+	ID           string       `json:"movement_id"`
+	IngredientID string       `json:"ingredient_id"`
+	Type         MovementType `json:"type"`
+	Delta        float64      `json:"delta"`
+	Reason       string       `json:"reason,omitempty"`
+	OrderID      string       `json:"order_id,omitempty"`
+	User         string       `json:"user,omitempty"`
+	// Позиция меню, ради которой списан ингредиент (продажа или отходы)
+	ProductID string `json:"product_id,omitempty"`
+	// Категория списания для движений типа waste
+	WasteReason WasteReason `json:"waste_reason,omitempty"`
+	// Себестоимость единицы и стоимость движения; стоимость имеет тот же знак, что и delta
+	UnitCost float64 `json:"unit_cost,omitempty"`
+	Cost     float64 `json:"cost,omitempty"`
+	// Партия, которая создана или списана этим движением
+	LotID string `json:"lot_id,omitempty"`
+	// Срок годности поступления; для пополнений из него создается партия
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// IsValid проверяет, что тип движения известен
+func (t MovementType) IsValid() bool {
+	switch t {
+	case MovementSale, MovementRestock, MovementAdjustment, MovementWaste, MovementTransfer, MovementReturn:
+		return true
+	}
+	return false
+}
+
+// Расхождение между остатком в инвентаре и остатком, рассчитанным по журналу движений
+type StockDrift struct {
+	IngredientID   string  `json:"ingredient_id"`
+	Name           string  `json:"name"`
+	ActualQuantity float64 `json:"actual_quantity"`
+	LedgerQuantity float64 `json:"ledger_quantity"`
+	Drift          float64 `json:"drift"`
+}
+
+// Запрос на относительное изменение остатка ингредиента
+type StockAdjustment struct {
+	Delta     float64      `json:"delta"`
+	Unit      string       `json:"unit,omitempty"`
+	Type      MovementType `json:"type,omitempty"`
+	Reason    string       `json:"reason"`
+	ExpiresAt *time.Time   `json:"expires_at,omitempty"`
+	// Стоимость единицы поступления в единицах запроса
+	UnitCost float64 `json:"unit_cost,omitempty"`
+}
+
+// Приемка поставки нескольких ингредиентов
+type StockReceipt struct {
+	Reason string             `json:"reason"`
+	Items  []StockReceiptItem `json:"items"`
+}
+
+type StockReceiptItem struct {
+	IngredientID string     `json:"ingredient_id"`
+	Quantity     float64    `json:"quantity"`
+	Unit         string     `json:"unit,omitempty"`
+	ExpiresAt    *time.Time `json:"expires_at,omitempty"`
+	// Стоимость единицы поступления в единицах запроса
+	UnitCost float64 `json:"unit_cost,omitempty"`
+}
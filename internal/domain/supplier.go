@@ -0,0 +1,67 @@
+package domain
+
+import "time"
+
+type Supplier struct {
+	ID           string         `json:"supplier_id"`
+	Name         string         `json:"name"`
+	ContactName  string         `json:"contact_name,omitempty"`
+	Phone        string         `json:"phone,omitempty"`
+	Email        string         `json:"email,omitempty"`
+	LeadTimeDays int            `json:"lead_time_days"`
+	Items        []SupplierItem `json:"items"`
+}
+
+// Ингредиент, который поставляет поставщик, с размером и ценой упаковки
+type SupplierItem struct {
+	IngredientID string  `json:"ingredient_id"`
+	PackSize     float64 `json:"pack_size"`
+	// Единица измерения упаковки; если не указана, используется единица инвентаря
+	Unit      string  `json:"unit,omitempty"`
+	PackPrice float64 `json:"pack_price"`
+}
+
+type PurchaseOrderStatus string
+
+const (
+	PurchaseOrderDraft             PurchaseOrderStatus = "draft"
+	PurchaseOrderSent              PurchaseOrderStatus = "sent"
+	PurchaseOrderPartiallyReceived PurchaseOrderStatus = "partially_received"
+	PurchaseOrderReceived          PurchaseOrderStatus = "received"
+)
+
+type PurchaseOrder struct {
+	ID         string              `json:"purchase_order_id"`
+	SupplierID string              `json:"supplier_id"`
+	Status     PurchaseOrderStatus `json:"status"`
+	Lines      []PurchaseOrderLine `json:"lines"`
+	Total      float64             `json:"total"`
+	CreatedAt  time.Time           `json:"created_at"`
+	SentAt     *time.Time          `json:"sent_at,omitempty"`
+	ExpectedAt *time.Time          `json:"expected_at,omitempty"`
+	ReceivedAt *time.Time          `json:"received_at,omitempty"`
+}
+
+// Строка заказа поставщику. Quantity и ReceivedQuantity указаны в единицах упаковки (Unit).
+type PurchaseOrderLine struct {
+	IngredientID     string  `json:"ingredient_id"`
+	Packs            int     `json:"packs"`
+	PackSize         float64 `json:"pack_size"`
+	Unit             string  `json:"unit,omitempty"`
+	PackPrice        float64 `json:"pack_price"`
+	Quantity         float64 `json:"quantity"`
+	ReceivedQuantity float64 `json:"received_quantity"`
+}
+
+// Outstanding возвращает количество, которое еще не получено по строке
+func (l *PurchaseOrderLine) Outstanding() float64 {
+	if l.ReceivedQuantity >= l.Quantity {
+		return 0
+	}
+	return l.Quantity - l.ReceivedQuantity
+}
+
+// Приемка товара по заказу поставщику
+type PurchaseOrderReceipt struct {
+	Items []StockReceiptItem `json:"items"`
+}
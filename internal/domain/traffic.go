@@ -0,0 +1,33 @@
+package domain
+
+import "time"
+
+// Отчет о загрузке по часам и дням недели для планирования смен
+type TrafficReport struct {
+	From     *time.Time `json:"start_date,omitempty"`
+	To       *time.Time `json:"end_date,omitempty"`
+	Timezone string     `json:"timezone"`
+	// Ячейки тепловой карты: 7 дней недели с понедельника × 24 часа
+	Cells []TrafficCell `json:"cells"`
+	// Итоги по часам суток за все дни недели
+	Hours []TrafficHour `json:"hours"`
+}
+
+// Завершенные заказы, созданные в указанный час указанного дня недели
+type TrafficCell struct {
+	Weekday      string  `json:"weekday"`
+	Hour         int     `json:"hour"`
+	Orders       int     `json:"orders"`
+	Revenue      float64 `json:"revenue"`
+	AverageItems float64 `json:"average_items"`
+}
+
+// Заказы за час суток и среднее время их приготовления (от создания до закрытия)
+type TrafficHour struct {
+	Hour         int     `json:"hour"`
+	Orders       int     `json:"orders"`
+	Revenue      float64 `json:"revenue"`
+	AverageItems float64 `json:"average_items"`
+	// Пусто, если время закрытия заказов этого часа неизвестно
+	AveragePrepMinutes *float64 `json:"average_prep_minutes,omitempty"`
+}
@@ -0,0 +1,116 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Измерение единицы: масса, объем или штуки
+type Dimension string
+
+const (
+	DimensionMass   Dimension = "mass"
+	DimensionVolume Dimension = "volume"
+	DimensionCount  Dimension = "count"
+)
+
+// Единица измерения и ее множитель относительно базовой единицы измерения
+// (граммы для массы, миллилитры для объема, штуки для счетных единиц)
+type Unit struct {
+	Name      string    `json:"name"`
+	Dimension Dimension `json:"dimension"`
+	Factor    float64   `json:"factor"`
+}
+
+// Реестр поддерживаемых единиц измерения
+var units = map[string]Unit{
+	// Масса
+	"mg": {Name: "mg", Dimension: DimensionMass, Factor: 0.001},
+	"g":  {Name: "g", Dimension: DimensionMass, Factor: 1},
+	"kg": {Name: "kg", Dimension: DimensionMass, Factor: 1000},
+	"oz": {Name: "oz", Dimension: DimensionMass, Factor: 28.349523125},
+	"lb": {Name: "lb", Dimension: DimensionMass, Factor: 453.59237},
+
+	// Объем
+	"ml":    {Name: "ml", Dimension: DimensionVolume, Factor: 1},
+	"cl":    {Name: "cl", Dimension: DimensionVolume, Factor: 10},
+	"l":     {Name: "l", Dimension: DimensionVolume, Factor: 1000},
+	"fl_oz": {Name: "fl_oz", Dimension: DimensionVolume, Factor: 29.5735295625},
+	"tsp":   {Name: "tsp", Dimension: DimensionVolume, Factor: 4.92892159375},
+	"tbsp":  {Name: "tbsp", Dimension: DimensionVolume, Factor: 14.78676478125},
+	"cup":   {Name: "cup", Dimension: DimensionVolume, Factor: 236.5882365},
+
+	// Штуки
+	"pcs":   {Name: "pcs", Dimension: DimensionCount, Factor: 1},
+	"shots": {Name: "shots", Dimension: DimensionCount, Factor: 1},
+}
+
+// Синонимы единиц измерения, которые встречаются в данных
+var unitAliases = map[string]string{
+	"gram":        "g",
+	"grams":       "g",
+	"kilogram":    "kg",
+	"kilograms":   "kg",
+	"milligram":   "mg",
+	"milligrams":  "mg",
+	"ounce":       "oz",
+	"ounces":      "oz",
+	"lbs":         "lb",
+	"pound":       "lb",
+	"pounds":      "lb",
+	"milliliter":  "ml",
+	"milliliters": "ml",
+	"liter":       "l",
+	"liters":      "l",
+	"litre":       "l",
+	"litres":      "l",
+	"floz":        "fl_oz",
+	"fl oz":       "fl_oz",
+	"cups":        "cup",
+	"pc":          "pcs",
+	"piece":       "pcs",
+	"pieces":      "pcs",
+	"unit":        "pcs",
+	"units":       "pcs",
+	"each":        "pcs",
+	"shot":        "shots",
+}
+
+// LookupUnit находит единицу измерения по имени или синониму
+func LookupUnit(name string) (Unit, bool) {
+	key := strings.ToLower(strings.TrimSpace(name))
+	if alias, ok := unitAliases[key]; ok {
+		key = alias
+	}
+	unit, ok := units[key]
+	return unit, ok
+}
+
+// CompatibleUnits проверяет, что единицы измерения относятся к одному измерению
+func CompatibleUnits(from, to string) error {
+	fromUnit, ok := LookupUnit(from)
+	if !ok {
+		return fmt.Errorf("unknown unit %q", from)
+	}
+	toUnit, ok := LookupUnit(to)
+	if !ok {
+		return fmt.Errorf("unknown unit %q", to)
+	}
+	if fromUnit.Dimension != toUnit.Dimension {
+		return fmt.Errorf("cannot convert %s (%s) to %s (%s)", fromUnit.Name, fromUnit.Dimension, toUnit.Name, toUnit.Dimension)
+	}
+	return nil
+}
+
+// ConvertUnit переводит количество из одной единицы измерения в другую
+func ConvertUnit(quantity float64, from, to string) (float64, error) {
+	if err := CompatibleUnits(from, to); err != nil {
+		return 0, err
+	}
+	fromUnit, _ := LookupUnit(from)
+	toUnit, _ := LookupUnit(to)
+	if fromUnit.Name == toUnit.Name {
+		return quantity, nil
+	}
+	return quantity * fromUnit.Factor / toUnit.Factor, nil
+}
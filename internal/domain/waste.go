@@ -0,0 +1,60 @@
+package domain
+
+// Категория причины списания в отходы
+type WasteReason string
+
+const (
+	WasteSpill          WasteReason = "spill"
+	WasteSpoilage       WasteReason = "spoilage"
+	WasteExpired        WasteReason = "expired"
+	WastePreparation    WasteReason = "preparation_error"
+	WasteCustomerReturn WasteReason = "customer_return"
+	WasteDamaged        WasteReason = "damaged"
+	WasteOther          WasteReason = "other"
+)
+
+// IsValid проверяет, что категория списания известна
+func (r WasteReason) IsValid() bool {
+	switch r {
+	case WasteSpill, WasteSpoilage, WasteExpired, WastePreparation, WasteCustomerReturn, WasteDamaged, WasteOther:
+		return true
+	}
+	return false
+}
+
+// Запрос на списание ингредиента в отходы
+type IngredientWaste struct {
+	IngredientID string      `json:"ingredient_id"`
+	Quantity     float64     `json:"quantity"`
+	Unit         string      `json:"unit,omitempty"`
+	Reason       WasteReason `json:"reason"`
+	Note         string      `json:"note,omitempty"`
+}
+
+// Запрос на списание приготовленных позиций меню
+type MenuItemWaste struct {
+	Quantity int         `json:"quantity"`
+	Reason   WasteReason `json:"reason"`
+	Note     string      `json:"note,omitempty"`
+}
+
+// Отчет об отходах за период
+type WasteReport struct {
+	TotalCost    float64            `json:"total_cost"`
+	ByIngredient []IngredientWasted `json:"by_ingredient"`
+	ByReason     []ReasonWasted     `json:"by_reason"`
+}
+
+type IngredientWasted struct {
+	IngredientID string  `json:"ingredient_id"`
+	Name         string  `json:"name"`
+	Quantity     float64 `json:"quantity"`
+	Unit         string  `json:"unit"`
+	Cost         float64 `json:"cost"`
+}
+
+type ReasonWasted struct {
+	Reason  WasteReason `json:"reason"`
+	Entries int         `json:"entries"`
+	Cost    float64     `json:"cost"`
+}
@@ -0,0 +1,190 @@
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Формат выгрузки
+type Format string
+
+const (
+	FormatJSON Format = "json"
+	FormatCSV  Format = "csv"
+	FormatXLSX Format = "xlsx"
+)
+
+// MIME-типы форматов выгрузки
+const (
+	ContentTypeJSON = "application/json"
+	ContentTypeCSV  = "text/csv"
+	ContentTypeXLSX = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+)
+
+func (f Format) IsValid() bool {
+	return f == FormatJSON || f == FormatCSV || f == FormatXLSX
+}
+
+// ContentType возвращает MIME-тип формата
+func (f Format) ContentType() string {
+	switch f {
+	case FormatCSV:
+		return ContentTypeCSV + "; charset=utf-8"
+	case FormatXLSX:
+		return ContentTypeXLSX
+	}
+	return ContentTypeJSON
+}
+
+// Table — таблица выгрузки с постоянным набором колонок.
+// Значения ячеек: string, int, float64, bool, time.Time, указатели на них или nil для пустой ячейки.
+type Table struct {
+	Name    string
+	Columns []string
+	Rows    [][]any
+}
+
+// Append добавляет строку таблицы
+func (t *Table) Append(values ...any) {
+	t.Rows = append(t.Rows, values)
+}
+
+// WriteCSV записывает таблицу в CSV построчно: заголовок из названий колонок, затем строки.
+// Текст, который табличный редактор принял бы за формулу, экранируется апострофом.
+func WriteCSV(w io.Writer, table Table) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(table.Columns); err != nil {
+		return err
+	}
+
+	record := make([]string, len(table.Columns))
+	for _, row := range table.Rows {
+		for i := range record {
+			record[i] = ""
+			if i < len(row) {
+				record[i] = csvValue(row[i])
+			}
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// WriteCSVSections записывает несколько таблиц в один CSV. Каждая таблица — раздел, который начинается
+// строкой с названием таблицы в квадратных скобках; разделы отделяются пустой строкой.
+// Единственная таблица записывается без названия, как в WriteCSV.
+func WriteCSVSections(w io.Writer, tables ...Table) error {
+	if len(tables) == 1 {
+		return WriteCSV(w, tables[0])
+	}
+	for i, table := range tables {
+		if i > 0 {
+			if _, err := io.WriteString(w, "\n"); err != nil {
+				return err
+			}
+		}
+		writer := csv.NewWriter(w)
+		if err := writer.Write([]string{"[" + table.Name + "]"}); err != nil {
+			return err
+		}
+		writer.Flush()
+		if err := writer.Error(); err != nil {
+			return err
+		}
+		if err := WriteCSV(w, table); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// csvValue форматирует значение ячейки для CSV
+func csvValue(value any) string {
+	text, isText := formatValue(value)
+	if isText && text != "" && strings.ContainsRune("=+-@\t\r", rune(text[0])) {
+		return "'" + text
+	}
+	return text
+}
+
+// formatValue форматирует значение ячейки и сообщает, является ли оно текстом (а не числом)
+func formatValue(value any) (string, bool) {
+	switch v := value.(type) {
+	case nil:
+		return "", false
+	case string:
+		return v, true
+	case int:
+		return strconv.Itoa(v), false
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), false
+	case bool:
+		return strconv.FormatBool(v), true
+	case time.Time:
+		if v.IsZero() {
+			return "", false
+		}
+		return v.Format(time.RFC3339), true
+	case *time.Time:
+		if v == nil {
+			return "", false
+		}
+		return formatValue(*v)
+	case *float64:
+		if v == nil {
+			return "", false
+		}
+		return formatValue(*v)
+	}
+	return fmt.Sprint(value), true
+}
+
+// ReadCSV читает CSV с заголовком и возвращает названия колонок и записи по этим названиям.
+// Названия колонок приводятся к нижнему регистру; апостроф, добавленный WriteCSV перед формулой, убирается.
+func ReadCSV(r io.Reader) ([]string, []map[string]string, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil, fmt.Errorf("CSV header is missing")
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	for i, column := range header {
+		header[i] = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(column, "\ufeff")))
+	}
+
+	records := make([]map[string]string, 0)
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			return header, records, nil
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(row) > len(header) {
+			line, _ := reader.FieldPos(0)
+			return nil, nil, fmt.Errorf("line %d has %d fields, header has %d", line, len(row), len(header))
+		}
+
+		record := make(map[string]string, len(header))
+		for i, value := range row {
+			value = strings.TrimSpace(value)
+			if len(value) > 1 && value[0] == '\'' && strings.ContainsRune("=+-@", rune(value[1])) {
+				value = value[1:]
+			}
+			record[header[i]] = value
+		}
+		records = append(records, record)
+	}
+}
@@ -0,0 +1,310 @@
+package export
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"hot-coffee/internal/domain"
+)
+
+// Дни недели в колонках прогноза, начиная с понедельника
+var weekdays = []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday, time.Sunday}
+
+// Orders выгружает заказы по строке на позицию заказа
+func Orders(orders []*domain.Order) []Table {
+	table := Table{
+		Name:    "orders",
+		Columns: []string{"order_id", "customer_id", "customer_name", "status", "created_at", "completed_at", "product_id", "quantity"},
+	}
+	for _, order := range orders {
+		if len(order.Items) == 0 {
+			table.Append(order.ID, order.CustomerID, order.CustomerName, string(order.Status), order.CreatedAt, order.CompletedAt, nil, nil)
+		}
+		for _, item := range order.Items {
+			table.Append(order.ID, order.CustomerID, order.CustomerName, string(order.Status), order.CreatedAt, order.CompletedAt, item.ProductID, item.Quantity)
+		}
+	}
+	return []Table{table}
+}
+
+// MenuItems выгружает позиции меню; состав записывается одной ячейкой вида "ingredient_id quantity unit; ...",
+// компоненты комбо — вида "product_id quantity; category:name quantity; ..."
+func MenuItems(menuItems []*domain.MenuItem) []Table {
+	table := Table{
+		Name:    "menu",
+		Columns: []string{"product_id", "name", "description", "category", "price", "ingredients", "components"},
+	}
+	for _, item := range menuItems {
+		ingredients := make([]string, 0, len(item.Ingredients))
+		for _, ingredient := range item.Ingredients {
+			ingredients = append(ingredients, strings.TrimSpace(fmt.Sprintf("%s %g %s", ingredient.IngredientID, ingredient.Quantity, ingredient.Unit)))
+		}
+		components := make([]string, 0, len(item.Components))
+		for _, component := range item.Components {
+			if component.IsChoice() {
+				components = append(components, fmt.Sprintf("category:%s %d", component.Category, component.Quantity))
+				continue
+			}
+			components = append(components, fmt.Sprintf("%s %d", component.ProductID, component.Quantity))
+		}
+		table.Append(item.ID, item.Name, item.Description, item.Category, item.Price, strings.Join(ingredients, "; "), strings.Join(components, "; "))
+	}
+	return []Table{table}
+}
+
+// InventoryItems выгружает остатки инвентаря
+func InventoryItems(inventoryItems []*domain.InventoryItem) []Table {
+	table := Table{
+		Name:    "inventory",
+		Columns: []string{"ingredient_id", "name", "quantity", "unit", "reorder_point", "par_level", "low_stock"},
+	}
+	for _, item := range inventoryItems {
+		table.Append(item.IngredientID, item.Name, item.Quantity, item.Unit, item.ReorderPoint, item.ParLevel, item.IsLowStock())
+	}
+	return []Table{table}
+}
+
+// SalesReport выгружает сумму продаж; при разбивке по периодам первой идет таблица периодов
+func SalesReport(report *domain.SalesReport) []Table {
+	summary := Table{
+		Name: "summary",
+		Columns: []string{"start_date", "end_date", "total_sales", "discounts", "net_sales", "tips", "compare", "compare_start_date",
+			"compare_end_date", "compare_total_sales", "change", "change_percent"},
+	}
+	if comparison := report.Comparison; comparison != nil {
+		summary.Append(report.From, report.To, report.TotalSales, report.Discounts, report.NetSales, report.Tips, string(comparison.Compare),
+			comparison.From, comparison.To, comparison.TotalSales, comparison.Change, comparison.ChangePercent)
+	} else {
+		summary.Append(report.From, report.To, report.TotalSales, report.Discounts, report.NetSales, report.Tips, nil, nil, nil, nil, nil, nil)
+	}
+
+	if report.GroupBy == "" {
+		return []Table{summary, paymentsByMethod("payments", report.PaymentsByMethod), promotionUsage("promotions", report.Promotions)}
+	}
+	periods := Table{
+		Name:    "periods",
+		Columns: []string{"period_start", "orders", "total_sales"},
+	}
+	for _, period := range report.Periods {
+		periods.Append(period.PeriodStart, period.Orders, period.TotalSales)
+	}
+	return []Table{periods, summary, paymentsByMethod("payments", report.PaymentsByMethod), promotionUsage("promotions", report.Promotions)}
+}
+
+// PopularItems выгружает рейтинг позиций меню
+func PopularItems(items []domain.ProductSales) []Table {
+	return []Table{productSales("popular_items", items)}
+}
+
+// BasketReport выгружает пары совместных покупок и итоги по среднему чеку
+func BasketReport(report *domain.BasketReport) []Table {
+	pairs := Table{
+		Name: "pairs",
+		Columns: []string{"product_a", "name_a", "product_b", "name_b", "orders", "support",
+			"confidence_a_to_b", "confidence_b_to_a", "lift"},
+	}
+	for _, pair := range report.Pairs {
+		pairs.Append(pair.ProductA, pair.NameA, pair.ProductB, pair.NameB, pair.Orders, pair.Support,
+			pair.ConfidenceAB, pair.ConfidenceBA, pair.Lift)
+	}
+
+	summary := Table{
+		Name:    "summary",
+		Columns: []string{"start_date", "end_date", "orders", "total_sales", "average_order_value", "average_items", "min_support"},
+	}
+	summary.Append(report.From, report.To, report.Orders, report.TotalSales, report.AverageOrderValue, report.AverageItems, report.MinSupport)
+	return []Table{pairs, summary}
+}
+
+// WasteReport выгружает отчет об отходах
+func WasteReport(report *domain.WasteReport) []Table {
+	byIngredient := Table{
+		Name:    "by_ingredient",
+		Columns: []string{"ingredient_id", "name", "quantity", "unit", "cost"},
+	}
+	for _, item := range report.ByIngredient {
+		byIngredient.Append(item.IngredientID, item.Name, item.Quantity, item.Unit, item.Cost)
+	}
+
+	byReason := Table{
+		Name:    "by_reason",
+		Columns: []string{"reason", "entries", "cost"},
+	}
+	for _, item := range report.ByReason {
+		byReason.Append(string(item.Reason), item.Entries, item.Cost)
+	}
+
+	summary := Table{Name: "summary", Columns: []string{"total_cost"}}
+	summary.Append(report.TotalCost)
+	return []Table{byIngredient, byReason, summary}
+}
+
+// InventoryValuation выгружает оценку запасов
+func InventoryValuation(valuation *domain.InventoryValuation) []Table {
+	items := Table{
+		Name: "items",
+		Columns: []string{"ingredient_id", "name", "unit", "opening_quantity", "opening_value", "received_quantity", "received_value",
+			"sold_quantity", "cogs", "returned_quantity", "returned_value", "wasted_quantity", "waste_value", "adjustment_value", "closing_quantity", "closing_value", "unit_cost"},
+	}
+	for _, item := range valuation.Items {
+		items.Append(item.IngredientID, item.Name, item.Unit, item.OpeningQuantity, item.OpeningValue, item.ReceivedQuantity, item.ReceivedValue,
+			item.SoldQuantity, item.COGS, item.ReturnedQuantity, item.ReturnedValue, item.WastedQuantity, item.WasteValue, item.AdjustmentValue, item.ClosingQuantity, item.ClosingValue, item.UnitCost)
+	}
+
+	summary := Table{
+		Name:    "summary",
+		Columns: []string{"costing_method", "start_date", "end_date", "opening_value", "closing_value"},
+	}
+	summary.Append(string(valuation.Method), valuation.From, valuation.To, valuation.OpeningValue, valuation.ClosingValue)
+	return []Table{items, summary}
+}
+
+// COGSReport выгружает себестоимость продаж
+func COGSReport(report *domain.COGSReport) []Table {
+	byProduct := Table{
+		Name:    "by_product",
+		Columns: []string{"product_id", "name", "cost"},
+	}
+	for _, item := range report.ByProduct {
+		byProduct.Append(item.ProductID, item.Name, item.Cost)
+	}
+
+	summary := Table{
+		Name:    "summary",
+		Columns: []string{"costing_method", "start_date", "end_date", "total_cogs", "returned_cogs"},
+	}
+	summary.Append(string(report.Method), report.From, report.To, report.Total, report.Returned)
+	return []Table{ingredientCOGS("by_ingredient", report.ByIngredient), byProduct, summary}
+}
+
+// IngredientUsage выгружает расход ингредиентов и его разбивку по позициям меню
+func IngredientUsage(report *domain.IngredientUsageReport) []Table {
+	items := Table{
+		Name: "items",
+		Columns: []string{"ingredient_id", "name", "unit", "expected_quantity", "sold_quantity", "returned_quantity", "wasted_quantity",
+			"adjusted_quantity", "actual_quantity", "unexplained_variance", "variance_percent", "flagged"},
+	}
+	byProduct := Table{
+		Name:    "by_product",
+		Columns: []string{"ingredient_id", "product_id", "name", "items_sold", "quantity"},
+	}
+	for _, item := range report.Items {
+		items.Append(item.IngredientID, item.Name, item.Unit, item.ExpectedQuantity, item.SoldQuantity, item.ReturnedQuantity, item.WastedQuantity,
+			item.AdjustedQuantity, item.ActualQuantity, item.Variance, item.VariancePercent, item.Flagged)
+		for _, product := range item.ByProduct {
+			byProduct.Append(item.IngredientID, product.ProductID, product.Name, product.ItemsSold, product.Quantity)
+		}
+	}
+	return []Table{items, byProduct}
+}
+
+// Forecast выгружает прогноз расхода; средний расход по дням недели — отдельными колонками
+func Forecast(forecast *domain.Forecast) []Table {
+	columns := []string{"ingredient_id", "name", "unit", "quantity", "on_order", "average_daily_usage", "projected_usage",
+		"days_until_stockout", "stockout_at", "lead_time_days", "suggested_quantity"}
+	for _, weekday := range weekdays {
+		columns = append(columns, strings.ToLower(weekday.String())+"_usage")
+	}
+
+	items := Table{Name: "items", Columns: columns}
+	for _, item := range forecast.Items {
+		row := []any{item.IngredientID, item.Name, item.Unit, item.Quantity, item.OnOrder, item.AverageDailyUsage, item.ProjectedUsage,
+			item.DaysUntilStockout, item.StockoutAt, item.LeadTimeDays, item.SuggestedQuantity}
+		for _, weekday := range weekdays {
+			row = append(row, item.WeekdayUsage[strings.ToLower(weekday.String())])
+		}
+		items.Append(row...)
+	}
+
+	summary := Table{
+		Name:    "summary",
+		Columns: []string{"generated_at", "lookback_days", "horizon_days"},
+	}
+	summary.Append(forecast.GeneratedAt, forecast.LookbackDays, forecast.HorizonDays)
+	return []Table{items, summary}
+}
+
+// TrafficReport выгружает загрузку по дням недели и часам и итоги по часам суток
+func TrafficReport(report *domain.TrafficReport) []Table {
+	cells := Table{
+		Name:    "heatmap",
+		Columns: []string{"weekday", "hour", "orders", "revenue", "average_items"},
+	}
+	for _, cell := range report.Cells {
+		cells.Append(cell.Weekday, cell.Hour, cell.Orders, cell.Revenue, cell.AverageItems)
+	}
+
+	hours := Table{
+		Name:    "hours",
+		Columns: []string{"hour", "orders", "revenue", "average_items", "average_prep_minutes"},
+	}
+	for _, hour := range report.Hours {
+		hours.Append(hour.Hour, hour.Orders, hour.Revenue, hour.AverageItems, hour.AveragePrepMinutes)
+	}
+	return []Table{cells, hours}
+}
+
+// DailyReport выгружает отчет о закрытии дня: итоги, оплаты, топ продаж и расход ингредиентов
+func DailyReport(report *domain.DailyReport) []Table {
+	summary := Table{
+		Name: "summary",
+		Columns: []string{"date", "closed", "closed_at", "closed_by", "orders", "pending_orders", "cancellations", "refunds",
+			"refunded", "gross_sales", "discounts", "tax_rate", "taxes", "net_sales", "tips"},
+	}
+	summary.Append(report.Date, report.Closed, report.ClosedAt, report.ClosedBy, report.Orders, report.PendingOrders, report.Cancellations, report.Refunds,
+		report.Refunded, report.GrossSales, report.Discounts, report.TaxRate, report.Taxes, report.NetSales, report.Tips)
+
+	return []Table{summary, paymentsByMethod("payments", report.PaymentsByMethod), productSales("top_items", report.TopItems), promotionUsage("promotions", report.Promotions),
+		ingredientCOGS("ingredient_usage", report.IngredientUsage)}
+}
+
+func paymentsByMethod(name string, amounts map[string]float64) Table {
+	table := Table{
+		Name:    name,
+		Columns: []string{"method", "amount"},
+	}
+	methods := make([]string, 0, len(amounts))
+	for method := range amounts {
+		methods = append(methods, method)
+	}
+	sort.Strings(methods)
+	for _, method := range methods {
+		table.Append(method, amounts[method])
+	}
+	return table
+}
+
+func promotionUsage(name string, items []domain.PromotionUsage) Table {
+	table := Table{
+		Name:    name,
+		Columns: []string{"promotion_id", "name", "orders", "discount"},
+	}
+	for _, item := range items {
+		table.Append(item.PromotionID, item.Name, item.Orders, item.Discount)
+	}
+	return table
+}
+
+func productSales(name string, items []domain.ProductSales) Table {
+	table := Table{
+		Name:    name,
+		Columns: []string{"product_id", "name", "quantity", "revenue", "bundle_quantity", "bundle_revenue"},
+	}
+	for _, item := range items {
+		table.Append(item.ProductID, item.Name, item.Quantity, item.Revenue, item.BundleQuantity, item.BundleRevenue)
+	}
+	return table
+}
+
+func ingredientCOGS(name string, items []domain.IngredientCOGS) Table {
+	table := Table{
+		Name:    name,
+		Columns: []string{"ingredient_id", "name", "unit", "quantity", "cost"},
+	}
+	for _, item := range items {
+		table.Append(item.IngredientID, item.Name, item.Unit, item.Quantity, item.Cost)
+	}
+	return table
+}
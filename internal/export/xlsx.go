@@ -0,0 +1,168 @@
+package export
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Максимальная длина названия листа книги
+const maxSheetName = 31
+
+// WriteXLSX записывает таблицы в книгу Office Open XML, по листу на таблицу.
+// Числа записываются числовыми ячейками, остальные значения — строками.
+func WriteXLSX(w io.Writer, tables ...Table) error {
+	archive := zip.NewWriter(w)
+
+	names := sheetNames(tables)
+	parts := []struct {
+		name    string
+		content string
+	}{
+		{"[Content_Types].xml", contentTypesXML(len(tables))},
+		{"_rels/.rels", rootRelsXML},
+		{"xl/workbook.xml", workbookXML(names)},
+		{"xl/_rels/workbook.xml.rels", workbookRelsXML(len(tables))},
+	}
+	for _, part := range parts {
+		file, err := archive.Create(part.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(file, part.content); err != nil {
+			return err
+		}
+	}
+
+	for i, table := range tables {
+		file, err := archive.Create(fmt.Sprintf("xl/worksheets/sheet%d.xml", i+1))
+		if err != nil {
+			return err
+		}
+		if err := writeSheet(file, table); err != nil {
+			return err
+		}
+	}
+	return archive.Close()
+}
+
+// writeSheet записывает лист: первая строка — названия колонок
+func writeSheet(w io.Writer, table Table) error {
+	buf := bufio.NewWriter(w)
+	buf.WriteString(xml.Header)
+	buf.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+
+	header := make([]any, len(table.Columns))
+	for i, column := range table.Columns {
+		header[i] = column
+	}
+	writeRow(buf, 1, header)
+	for i, row := range table.Rows {
+		writeRow(buf, i+2, row)
+	}
+
+	buf.WriteString(`</sheetData></worksheet>`)
+	return buf.Flush()
+}
+
+func writeRow(buf *bufio.Writer, number int, values []any) {
+	fmt.Fprintf(buf, `<row r="%d">`, number)
+	for i, value := range values {
+		text, isText := formatValue(value)
+		if text == "" {
+			continue
+		}
+		ref := columnName(i) + strconv.Itoa(number)
+		if isText {
+			fmt.Fprintf(buf, `<c r="%s" t="inlineStr"><is><t xml:space="preserve">`, ref)
+			xml.EscapeText(buf, []byte(text))
+			buf.WriteString(`</t></is></c>`)
+			continue
+		}
+		fmt.Fprintf(buf, `<c r="%s"><v>%s</v></c>`, ref, text)
+	}
+	buf.WriteString(`</row>`)
+}
+
+// columnName возвращает буквенное обозначение колонки: A, B, ..., Z, AA, AB, ...
+func columnName(index int) string {
+	name := ""
+	for index++; index > 0; index = (index - 1) / 26 {
+		name = string(rune('A'+(index-1)%26)) + name
+	}
+	return name
+}
+
+// sheetNames возвращает допустимые и уникальные названия листов
+func sheetNames(tables []Table) []string {
+	names := make([]string, len(tables))
+	used := make(map[string]bool)
+	for i, table := range tables {
+		name := strings.Map(func(r rune) rune {
+			if strings.ContainsRune(`[]:*?/\`, r) {
+				return '_'
+			}
+			return r
+		}, table.Name)
+		if name == "" {
+			name = fmt.Sprintf("Sheet%d", i+1)
+		}
+		if runes := []rune(name); len(runes) > maxSheetName {
+			name = string(runes[:maxSheetName])
+		}
+		for base, n := name, 2; used[strings.ToLower(name)]; n++ {
+			suffix := fmt.Sprintf(" (%d)", n)
+			runes := []rune(base)
+			name = string(runes[:min(len(runes), maxSheetName-len(suffix))]) + suffix
+		}
+		used[strings.ToLower(name)] = true
+		names[i] = name
+	}
+	return names
+}
+
+const rootRelsXML = xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+	`</Relationships>`
+
+func contentTypesXML(sheets int) string {
+	var b strings.Builder
+	b.WriteString(xml.Header)
+	b.WriteString(`<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">`)
+	b.WriteString(`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>`)
+	b.WriteString(`<Default Extension="xml" ContentType="application/xml"/>`)
+	b.WriteString(`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>`)
+	for i := 1; i <= sheets; i++ {
+		fmt.Fprintf(&b, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, i)
+	}
+	b.WriteString(`</Types>`)
+	return b.String()
+}
+
+func workbookXML(names []string) string {
+	var b strings.Builder
+	b.WriteString(xml.Header)
+	b.WriteString(`<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets>`)
+	for i, name := range names {
+		b.WriteString(`<sheet name="`)
+		xml.EscapeText(&b, []byte(name))
+		fmt.Fprintf(&b, `" sheetId="%d" r:id="rId%d"/>`, i+1, i+1)
+	}
+	b.WriteString(`</sheets></workbook>`)
+	return b.String()
+}
+
+func workbookRelsXML(sheets int) string {
+	var b strings.Builder
+	b.WriteString(xml.Header)
+	b.WriteString(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`)
+	for i := 1; i <= sheets; i++ {
+		fmt.Fprintf(&b, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>`, i, i)
+	}
+	b.WriteString(`</Relationships>`)
+	return b.String()
+}
@@ -2,15 +2,38 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
+
+	"hot-coffee/internal/domain"
+	"hot-coffee/internal/export"
 )
 
 // Обработчик запроса на получение общей суммы продаж
 func (h *CustomHandler) GetTotalSalesHandler(w http.ResponseWriter, r *http.Request) {
 	h.LoggerINFO.Println("GetTotalSalesHandler - Received request to get total sales.")
 
+	// Разбираем формат ответа: JSON, CSV или XLSX
+	format, err := parseExportFormat(r)
+	if err != nil {
+		h.respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Разбираем период, часовой пояс, разбивку и сравнение
+	filter, err := parseReportFilter(r)
+	if err != nil {
+		h.respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
 	// Получаем общую сумму продаж через сервис
-	totalSales, err := h.Service.GetTotalSales()
+	report, err := h.Service.GetTotalSales(filter)
+	if errors.Is(err, domain.ErrTooManyPeriods) {
+		// Период без start_date определяется заказами и может не уложиться в лимит интервалов
+		h.respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
 	if err != nil {
 		// Обрабатываем ошибку и возвращаем клиенту ошибку сервера
 		h.respondWithError(w, http.StatusInternalServerError, err.Error())
@@ -18,10 +41,14 @@ func (h *CustomHandler) GetTotalSalesHandler(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
+	if format != export.FormatJSON {
+		h.respondWithExport(w, format, "total-sales", export.SalesReport(report))
+		return
+	}
+
 	// Формируем ответ в формате JSON
-	response := map[string]float64{"total_sales": totalSales}
 	w.Header().Set("Content-Type", "application/json")
-	err = json.NewEncoder(w).Encode(response)
+	err = json.NewEncoder(w).Encode(report)
 	if err != nil {
 		// Обрабатываем ошибку при кодировании ответа
 		h.LoggerERROR.Printf("GetTotalSalesHandler - Error encoding response: %v", err)
@@ -34,8 +61,30 @@ func (h *CustomHandler) GetTotalSalesHandler(w http.ResponseWriter, r *http.Requ
 // Обработчик запроса на получение популярных товаров
 func (h *CustomHandler) GetPopularItemsHandler(w http.ResponseWriter, r *http.Request) {
 	h.LoggerINFO.Println("GetPopularItemsHandler - Received request to get popular items.")
+
+	// Разбираем формат ответа: JSON, CSV или XLSX
+	format, err := parseExportFormat(r)
+	if err != nil {
+		h.respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Разбираем период и часовой пояс
+	filter, err := parseReportFilter(r)
+	if err != nil {
+		h.respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Разбираем сортировку, вид отчета и ограничение количества
+	options, err := parsePopularItemsOptions(r)
+	if err != nil {
+		h.respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
 	// Получаем популярные товары через сервис
-	popularItems, err := h.Service.GetPopularItems()
+	popularItems, err := h.Service.GetPopularItems(filter, options)
 	if err != nil {
 		// Обрабатываем ошибку и возвращаем клиенту ошибку сервера
 		h.respondWithError(w, http.StatusInternalServerError, err.Error())
@@ -43,6 +92,11 @@ func (h *CustomHandler) GetPopularItemsHandler(w http.ResponseWriter, r *http.Re
 		return
 	}
 
+	if format != export.FormatJSON {
+		h.respondWithExport(w, format, "popular-items", export.PopularItems(popularItems))
+		return
+	}
+
 	// Формируем ответ в формате JSON
 	w.Header().Set("Content-Type", "application/json")
 	err = json.NewEncoder(w).Encode(popularItems)
@@ -54,3 +108,53 @@ func (h *CustomHandler) GetPopularItemsHandler(w http.ResponseWriter, r *http.Re
 	}
 	h.LoggerINFO.Println("GetPopularItemsHandler - Successfully responded with popular items.")
 }
+
+// Обработчик запроса на анализ корзины
+func (h *CustomHandler) GetBasketReportHandler(w http.ResponseWriter, r *http.Request) {
+	h.LoggerINFO.Println("GetBasketReportHandler - Received request to get basket report.")
+
+	// Разбираем формат ответа: JSON, CSV или XLSX
+	format, err := parseExportFormat(r)
+	if err != nil {
+		h.respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Разбираем период с учетом часового пояса
+	from, to, err := parseDateRange(r)
+	if err != nil {
+		h.respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Разбираем минимальную поддержку и ограничение количества пар
+	options, err := parseBasketOptions(r)
+	if err != nil {
+		h.respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Получаем анализ корзины через сервис
+	report, err := h.Service.GetBasketReport(domain.ReportFilter{From: from, To: to}, options)
+	if err != nil {
+		// Обрабатываем ошибку и возвращаем клиенту ошибку сервера
+		h.respondWithError(w, http.StatusInternalServerError, err.Error())
+		h.LoggerERROR.Printf("Error getting basket report: %v", err)
+		return
+	}
+
+	if format != export.FormatJSON {
+		h.respondWithExport(w, format, "basket", export.BasketReport(report))
+		return
+	}
+
+	// Формируем ответ в формате JSON
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		// Обрабатываем ошибку при кодировании ответа
+		h.LoggerERROR.Printf("GetBasketReportHandler - Error encoding response: %v", err)
+		h.respondWithError(w, http.StatusInternalServerError, "Failed to encode response")
+		return
+	}
+	h.LoggerINFO.Println("GetBasketReportHandler - Successfully responded with basket report.")
+}
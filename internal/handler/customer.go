@@ -0,0 +1,164 @@
+package handler
+
+import (
+	"net/http"
+
+	"hot-coffee/internal/export"
+)
+
+// CustomerHandler обрабатывает запросы для работы с клиентами (получение всех, добавление нового)
+func (h *CustomHandler) CustomerHandler(w http.ResponseWriter, r *http.Request) {
+	h.LoggerINFO.Printf("CustomerHandler - %s request received", r.Method)
+
+	switch r.Method {
+	case http.MethodGet:
+		h.getAllCustomers(w, r)
+	case http.MethodPost:
+		h.addCustomer(w, r)
+	default:
+		h.LoggerERROR.Printf("CustomerHandler - Method %s not allowed", r.Method)
+		h.respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// CustomerByIDHandler обрабатывает запросы для работы с клиентом по ID (получение, обновление, удаление)
+func (h *CustomHandler) CustomerByIDHandler(w http.ResponseWriter, r *http.Request) {
+	h.LoggerINFO.Printf("CustomerByIDHandler - %s request received", r.Method)
+
+	switch r.Method {
+	case http.MethodGet:
+		h.getCustomerByID(w, r)
+	case http.MethodPut:
+		h.updateCustomerByID(w, r)
+	case http.MethodDelete:
+		h.deleteCustomerByID(w, r)
+	default:
+		h.LoggerERROR.Printf("CustomerByIDHandler - Method %s not allowed", r.Method)
+		h.respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// CustomerOrdersHandler обрабатывает запрос истории заказов клиента
+func (h *CustomHandler) CustomerOrdersHandler(w http.ResponseWriter, r *http.Request) {
+	h.LoggerINFO.Printf("CustomerOrdersHandler - %s request received", r.Method)
+
+	switch r.Method {
+	case http.MethodGet:
+		h.getCustomerOrders(w, r)
+	default:
+		h.LoggerERROR.Printf("CustomerOrdersHandler - Method %s not allowed", r.Method)
+		h.respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// getAllCustomers получает всех клиентов; параметр q ищет по имени, телефону и почте
+func (h *CustomHandler) getAllCustomers(w http.ResponseWriter, r *http.Request) {
+	data, status, err := h.Service.GetAllCustomers(r.URL.Query().Get("q"))
+	if err != nil {
+		h.LoggerERROR.Println(err)
+		h.respondWithError(w, status, err.Error())
+		return
+	}
+	h.respondWithJSON(w, status, data)
+}
+
+// addCustomer добавляет нового клиента и возвращает его с присвоенным ID
+func (h *CustomHandler) addCustomer(w http.ResponseWriter, r *http.Request) {
+	body, ok := h.readJSONBody(w, r)
+	if !ok {
+		return
+	}
+
+	data, status, err := h.Service.AddCustomer(body)
+	if err != nil {
+		h.LoggerERROR.Println("Service error:", err)
+		h.respondWithError(w, status, err.Error())
+		return
+	}
+
+	h.respondWithJSON(w, status, data)
+	h.LoggerINFO.Println("addCustomer - Customer added successfully")
+}
+
+// getCustomerByID получает клиента по его ID вместе со статистикой заказов
+func (h *CustomHandler) getCustomerByID(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	data, status, err := h.Service.GetCustomerByID(id)
+	if err != nil {
+		h.LoggerERROR.Println(err)
+		h.respondWithError(w, status, err.Error())
+		return
+	}
+	h.respondWithJSON(w, status, data)
+}
+
+// updateCustomerByID обновляет клиента по его ID
+func (h *CustomHandler) updateCustomerByID(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	body, ok := h.readJSONBody(w, r)
+	if !ok {
+		return
+	}
+
+	status, err := h.Service.UpdateCustomerByID(id, body)
+	if err != nil {
+		h.LoggerERROR.Println(err)
+		h.respondWithError(w, status, err.Error())
+		return
+	}
+
+	w.WriteHeader(status)
+	h.LoggerINFO.Printf("updateCustomerByID - Customer with ID %s updated successfully", id)
+}
+
+// deleteCustomerByID удаляет клиента по его ID
+func (h *CustomHandler) deleteCustomerByID(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	status, err := h.Service.DeleteCustomerByID(id)
+	if err != nil {
+		h.LoggerERROR.Println(err)
+		h.respondWithError(w, status, err.Error())
+		return
+	}
+
+	w.WriteHeader(status)
+	h.LoggerINFO.Printf("deleteCustomerByID - Customer with ID %s deleted successfully", id)
+}
+
+// getCustomerOrders получает историю заказов клиента с теми же фильтрами, сортировкой и форматами, что и список заказов
+func (h *CustomHandler) getCustomerOrders(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	format, err := parseExportFormat(r)
+	if err != nil {
+		h.respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	filter, err := parseOrderFilter(r)
+	if err != nil {
+		h.respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	options, err := parseListOptions(r)
+	if err != nil {
+		h.respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	data, info, status, err := h.Service.GetCustomerOrders(id, filter, options)
+	if err != nil {
+		h.LoggerERROR.Println(err)
+		h.respondWithError(w, status, err.Error())
+		return
+	}
+	setPaginationHeaders(w, r, info)
+	if format != export.FormatJSON {
+		respondWithExportedList(h, w, format, "customer-orders", data, export.Orders)
+		return
+	}
+	h.respondWithJSON(w, status, data)
+}
@@ -0,0 +1,88 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"hot-coffee/internal/domain"
+	"hot-coffee/internal/export"
+)
+
+// GetDailyReportHandler возвращает отчет о закрытии рабочего дня
+func (h *CustomHandler) GetDailyReportHandler(w http.ResponseWriter, r *http.Request) {
+	h.LoggerINFO.Printf("GetDailyReportHandler - %s request received", r.Method)
+
+	if r.Method != http.MethodGet {
+		h.LoggerERROR.Printf("GetDailyReportHandler - Method %s not allowed", r.Method)
+		h.respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	date, err := parseBusinessDay(r.PathValue("date"))
+	if err != nil {
+		h.respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	format, err := parseExportFormat(r)
+	if err != nil {
+		h.respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	data, status, err := h.Service.GetDailyReport(date)
+	if err != nil {
+		h.LoggerERROR.Println(err)
+		h.respondWithError(w, status, err.Error())
+		return
+	}
+
+	if format != export.FormatJSON {
+		var report domain.DailyReport
+		if err := json.Unmarshal(data, &report); err != nil {
+			h.LoggerERROR.Printf("GetDailyReportHandler - Error decoding report for export: %v", err)
+			h.respondWithError(w, http.StatusInternalServerError, "Failed to export response")
+			return
+		}
+		h.respondWithExport(w, format, "daily-"+report.Date, export.DailyReport(&report))
+		return
+	}
+	h.respondWithJSON(w, status, data)
+}
+
+// CloseDayHandler закрывает рабочий день и сохраняет его итоги
+func (h *CustomHandler) CloseDayHandler(w http.ResponseWriter, r *http.Request) {
+	h.LoggerINFO.Printf("CloseDayHandler - %s request received", r.Method)
+
+	if r.Method != http.MethodPost {
+		h.LoggerERROR.Printf("CloseDayHandler - Method %s not allowed", r.Method)
+		h.respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	date, err := parseBusinessDay(r.PathValue("date"))
+	if err != nil {
+		h.respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	data, status, err := h.Service.CloseDay(date, userFromRequest(r))
+	if err != nil {
+		h.LoggerERROR.Println(err)
+		h.respondWithError(w, status, err.Error())
+		return
+	}
+	h.LoggerINFO.Printf("CloseDayHandler - Business day %s closed successfully", r.PathValue("date"))
+	h.respondWithJSON(w, status, data)
+}
+
+// parseBusinessDay разбирает дату рабочего дня в формате YYYY-MM-DD в местном часовом поясе
+func parseBusinessDay(value string) (time.Time, error) {
+	date, err := time.ParseInLocation(time.DateOnly, value, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q, expected YYYY-MM-DD", value)
+	}
+	return date, nil
+}
@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+
+	"hot-coffee/internal/export"
+)
+
+// parseExportFormat определяет формат ответа: параметр format важнее заголовка Accept.
+// Из Accept выбирается первый поддерживаемый тип; по умолчанию ответ в JSON.
+func parseExportFormat(r *http.Request) (export.Format, error) {
+	if value := r.URL.Query().Get("format"); value != "" {
+		format := export.Format(strings.ToLower(value))
+		if !format.IsValid() {
+			return "", fmt.Errorf("invalid format %q, expected json, csv or xlsx", value)
+		}
+		return format, nil
+	}
+
+	for _, accepted := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(accepted))
+		if err != nil {
+			continue
+		}
+		switch mediaType {
+		case export.ContentTypeCSV:
+			return export.FormatCSV, nil
+		case export.ContentTypeXLSX:
+			return export.FormatXLSX, nil
+		case export.ContentTypeJSON:
+			return export.FormatJSON, nil
+		}
+	}
+	return export.FormatJSON, nil
+}
+
+// respondWithExport отправляет таблицы файлом: в CSV каждая таблица идет отдельным разделом, в XLSX — отдельным листом
+func (h *CustomHandler) respondWithExport(w http.ResponseWriter, format export.Format, name string, tables []export.Table) {
+	w.Header().Set("Content-Type", format.ContentType())
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", name+"."+string(format)))
+	w.Header().Add("Vary", "Accept")
+
+	var err error
+	if format == export.FormatCSV {
+		err = export.WriteCSVSections(w, tables...)
+	} else {
+		err = export.WriteXLSX(w, tables...)
+	}
+	if err != nil {
+		h.LoggerERROR.Printf("Error writing %s export %s: %v", format, name, err)
+	}
+}
+
+// respondWithExportedList десериализует полученный от сервиса список и отправляет его файлом
+func respondWithExportedList[T any](h *CustomHandler, w http.ResponseWriter, format export.Format, name string, data []byte, tables func([]*T) []export.Table) {
+	var items []*T
+	if err := json.Unmarshal(data, &items); err != nil {
+		h.LoggerERROR.Printf("Error decoding %s for export: %v", name, err)
+		h.respondWithError(w, http.StatusInternalServerError, "Failed to export response")
+		return
+	}
+	h.respondWithExport(w, format, name, tables(items))
+}
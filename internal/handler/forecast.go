@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"hot-coffee/internal/export"
+)
+
+// Обработчик прогноза расхода ингредиентов
+func (h *CustomHandler) GetForecastHandler(w http.ResponseWriter, r *http.Request) {
+	h.LoggerINFO.Println("GetForecastHandler - Received request to get stock forecast.")
+
+	format, err := parseExportFormat(r)
+	if err != nil {
+		h.respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	lookbackDays, err := parseDays(r, "lookback_days", defaultLookbackDays)
+	if err != nil {
+		h.respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if lookbackDays == 0 {
+		h.respondWithError(w, http.StatusBadRequest, "lookback_days must be greater than zero")
+		return
+	}
+
+	horizonDays, err := parseDays(r, "horizon_days", defaultHorizonDays)
+	if err != nil {
+		h.respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	loc, err := parseLocation(r)
+	if err != nil {
+		h.respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	forecast, err := h.Service.GetForecast(lookbackDays, horizonDays, loc)
+	if err != nil {
+		h.respondWithError(w, http.StatusInternalServerError, err.Error())
+		h.LoggerERROR.Printf("Error getting stock forecast: %v", err)
+		return
+	}
+
+	if format != export.FormatJSON {
+		h.respondWithExport(w, format, "forecast", export.Forecast(forecast))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(forecast); err != nil {
+		h.LoggerERROR.Printf("GetForecastHandler - Error encoding response: %v", err)
+		h.respondWithError(w, http.StatusInternalServerError, "Failed to encode response")
+		return
+	}
+	h.LoggerINFO.Println("GetForecastHandler - Successfully responded with stock forecast.")
+}
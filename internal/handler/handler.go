@@ -1,7 +1,9 @@
 package handler
 
 import (
+	"io"
 	"log"
+	"net/http"
 
 	"hot-coffee/internal/service"
 )
@@ -17,3 +19,68 @@ type CustomHandler struct {
 func NewCustomHandler(serviceObject service.ServiceModule) *CustomHandler {
 	return &CustomHandler{Service: serviceObject}
 }
+
+// userFromRequest возвращает имя сотрудника, выполняющего запрос, из заголовка X-User
+func userFromRequest(r *http.Request) string {
+	return r.Header.Get("X-User")
+}
+
+// readJSONBody проверяет Content-Type и читает тело запроса.
+// При ошибке отправляет ответ клиенту и возвращает false.
+func (h *CustomHandler) readJSONBody(w http.ResponseWriter, r *http.Request) ([]byte, bool) {
+	if r.Header.Get("Content-Type") != "application/json" {
+		h.respondWithError(w, http.StatusUnsupportedMediaType, "Content-Type must be application/json")
+		return nil, false
+	}
+
+	defer r.Body.Close()
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.LoggerERROR.Println("Error reading request body:", err)
+		h.respondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return nil, false
+	}
+	return body, true
+}
+
+// respondWithService отправляет клиенту ответ сервиса или его ошибку
+func (h *CustomHandler) respondWithService(w http.ResponseWriter, call func() ([]byte, int, error)) {
+	data, status, err := call()
+	if err != nil {
+		h.LoggerERROR.Println(err)
+		h.respondWithError(w, status, err.Error())
+		return
+	}
+	h.respondWithJSON(w, status, data)
+}
+
+// saveWithService передает тело запроса сервису и отвечает статусом без тела
+func (h *CustomHandler) saveWithService(w http.ResponseWriter, r *http.Request, action string, call func([]byte) (int, error)) {
+	body, ok := h.readJSONBody(w, r)
+	if !ok {
+		return
+	}
+
+	status, err := call(body)
+	if err != nil {
+		h.LoggerERROR.Println("Service error:", err)
+		h.respondWithError(w, status, err.Error())
+		return
+	}
+
+	w.WriteHeader(status)
+	h.LoggerINFO.Printf("%s - completed successfully", action)
+}
+
+// deleteWithService удаляет запись по ID через сервис
+func (h *CustomHandler) deleteWithService(w http.ResponseWriter, action, id string, call func(string) (int, error)) {
+	status, err := call(id)
+	if err != nil {
+		h.LoggerERROR.Println(err)
+		h.respondWithError(w, status, err.Error())
+		return
+	}
+
+	w.WriteHeader(status)
+	h.LoggerINFO.Printf("%s - record with ID %s deleted successfully", action, id)
+}
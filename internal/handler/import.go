@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"hot-coffee/internal/domain"
+	"hot-coffee/internal/export"
+)
+
+// MenuImportHandler импортирует позиции меню из JSON-массива или CSV
+func (h *CustomHandler) MenuImportHandler(w http.ResponseWriter, r *http.Request) {
+	h.LoggerINFO.Printf("MenuImportHandler - %s request received", r.Method)
+
+	format, options, body, ok := h.readImportRequest(w, r)
+	if !ok {
+		return
+	}
+
+	data, status, err := h.Service.ImportMenuItems(body, format, options)
+	if err != nil {
+		h.LoggerERROR.Println(err)
+		h.respondWithError(w, status, err.Error())
+		return
+	}
+	h.LoggerINFO.Printf("MenuImportHandler - Import finished with status %d", status)
+	h.respondWithJSON(w, status, data)
+}
+
+// InventoryImportHandler импортирует ингредиенты из JSON-массива или CSV
+func (h *CustomHandler) InventoryImportHandler(w http.ResponseWriter, r *http.Request) {
+	h.LoggerINFO.Printf("InventoryImportHandler - %s request received", r.Method)
+
+	format, options, body, ok := h.readImportRequest(w, r)
+	if !ok {
+		return
+	}
+
+	data, status, err := h.Service.ImportInventoryItems(body, format, options, userFromRequest(r))
+	if err != nil {
+		h.LoggerERROR.Println(err)
+		h.respondWithError(w, status, err.Error())
+		return
+	}
+	h.LoggerINFO.Printf("InventoryImportHandler - Import finished with status %d", status)
+	h.respondWithJSON(w, status, data)
+}
+
+// readImportRequest проверяет метод, определяет формат по Content-Type, разбирает параметры и читает тело запроса.
+// При ошибке отправляет ответ клиенту и возвращает false.
+func (h *CustomHandler) readImportRequest(w http.ResponseWriter, r *http.Request) (export.Format, domain.ImportOptions, []byte, bool) {
+	if r.Method != http.MethodPost {
+		h.respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return "", domain.ImportOptions{}, nil, false
+	}
+
+	var format export.Format
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	switch mediaType {
+	case export.ContentTypeJSON:
+		format = export.FormatJSON
+	case export.ContentTypeCSV:
+		format = export.FormatCSV
+	default:
+		h.respondWithError(w, http.StatusUnsupportedMediaType, "Content-Type must be application/json or text/csv")
+		return "", domain.ImportOptions{}, nil, false
+	}
+
+	options, err := parseImportOptions(r)
+	if err != nil {
+		h.respondWithError(w, http.StatusBadRequest, err.Error())
+		return "", domain.ImportOptions{}, nil, false
+	}
+
+	defer r.Body.Close()
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.LoggerERROR.Println("Error reading request body:", err)
+		h.respondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return "", domain.ImportOptions{}, nil, false
+	}
+	return format, options, body, true
+}
+
+// parseImportOptions разбирает параметры mode (insert или upsert, по умолчанию insert) и dry_run
+func parseImportOptions(r *http.Request) (domain.ImportOptions, error) {
+	query := r.URL.Query()
+	options := domain.ImportOptions{Mode: domain.ImportInsert}
+
+	if value := query.Get("mode"); value != "" {
+		options.Mode = domain.ImportMode(value)
+		if !options.Mode.IsValid() {
+			return options, fmt.Errorf("invalid mode %q, expected insert or upsert", value)
+		}
+	}
+
+	if value := query.Get("dry_run"); value != "" {
+		dryRun, err := strconv.ParseBool(value)
+		if err != nil {
+			return options, fmt.Errorf("invalid dry_run %q, expected true or false", value)
+		}
+		options.DryRun = dryRun
+	}
+	return options, nil
+}
@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"hot-coffee/internal/export"
+)
+
+// Обработчик отчета о расходе ингредиентов
+func (h *CustomHandler) GetIngredientUsageHandler(w http.ResponseWriter, r *http.Request) {
+	h.LoggerINFO.Println("GetIngredientUsageHandler - Received request to get ingredient usage.")
+
+	format, err := parseExportFormat(r)
+	if err != nil {
+		h.respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	from, to, err := parseDateRange(r)
+	if err != nil {
+		h.respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	threshold, err := parseVarianceThreshold(r)
+	if err != nil {
+		h.respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	report, err := h.Service.GetIngredientUsage(from, to, threshold)
+	if err != nil {
+		h.respondWithError(w, http.StatusInternalServerError, err.Error())
+		h.LoggerERROR.Printf("Error getting ingredient usage: %v", err)
+		return
+	}
+
+	if format != export.FormatJSON {
+		h.respondWithExport(w, format, "ingredient-usage", export.IngredientUsage(report))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		h.LoggerERROR.Printf("GetIngredientUsageHandler - Error encoding response: %v", err)
+		h.respondWithError(w, http.StatusInternalServerError, "Failed to encode response")
+		return
+	}
+	h.LoggerINFO.Println("GetIngredientUsageHandler - Successfully responded with ingredient usage.")
+}
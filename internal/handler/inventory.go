@@ -3,6 +3,8 @@ package handler
 import (
 	"io"
 	"net/http"
+
+	"hot-coffee/internal/export"
 )
 
 // Обработчик для работы с инвентарем
@@ -37,24 +39,67 @@ func (h *CustomHandler) InventoryByIDHandler(w http.ResponseWriter, r *http.Requ
 	}
 }
 
-// Получение всего инвентаря
+// Обработчик для получения ингредиентов с низким остатком
+func (h *CustomHandler) LowStockHandler(w http.ResponseWriter, r *http.Request) {
+	h.LoggerINFO.Printf("LowStockHandler - %s request received", r.Method)
+
+	if r.Method != http.MethodGet {
+		h.respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		h.LoggerERROR.Printf("LowStockHandler - Method %s not allowed", r.Method)
+		return
+	}
+
+	// Получаем ингредиенты с низким остатком через сервис
+	data, status, err := h.Service.GetLowStockItems()
+	if err != nil {
+		h.LoggerERROR.Println(err)
+		h.respondWithError(w, status, err.Error())
+		return
+	}
+
+	h.respondWithJSON(w, status, data)
+}
+
+// Получение инвентаря с фильтрами, сортировкой и постраничным выводом
 func (h *CustomHandler) getAllInventory(w http.ResponseWriter, r *http.Request) {
-	// Проверяем заголовок Content-Type
-	if r.Header.Get("Content-Type") != "application/json" {
+	// Разбираем формат ответа; для JSON проверяем заголовок Content-Type
+	format, err := parseExportFormat(r)
+	if err != nil {
+		h.respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if format == export.FormatJSON && r.Header.Get("Content-Type") != "application/json" {
 		h.respondWithError(w, http.StatusUnsupportedMediaType, "Content-Type must be application/json")
 		return
 	}
 
 	h.LoggerINFO.Println("getAllInventory - Fetching all inventory items")
 
-	// Получаем все элементы через сервис
-	data, status, err := h.Service.GetAllInventoryItems()
+	// Разбираем фильтры, сортировку и страницу
+	filter, err := parseInventoryFilter(r)
+	if err != nil {
+		h.respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	options, err := parseListOptions(r)
+	if err != nil {
+		h.respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Получаем элементы через сервис
+	data, info, status, err := h.Service.GetAllInventoryItems(filter, options)
 	if err != nil {
 		h.LoggerERROR.Println(err)
 		h.respondWithError(w, status, err.Error())
 		return
 	}
 
+	setPaginationHeaders(w, r, info)
+	if format != export.FormatJSON {
+		respondWithExportedList(h, w, format, "inventory", data, export.InventoryItems)
+		return
+	}
 	w.Header().Set("Content-Type", "application/json")
 	_, err = w.Write(data)
 	if err != nil {
@@ -81,7 +126,7 @@ func (h *CustomHandler) addInventory(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Добавляем элемент через сервис
-	status, err := h.Service.AddInventoryItem(data)
+	status, err := h.Service.AddInventoryItem(data, userFromRequest(r))
 	if err != nil {
 		h.LoggerERROR.Println("Service error:", err)
 		h.respondWithError(w, status, err.Error())
@@ -140,7 +185,7 @@ func (h *CustomHandler) updateInventoryByID(w http.ResponseWriter, r *http.Reque
 	defer r.Body.Close()
 
 	// Обновляем элемент через сервис
-	if status, err := h.Service.UpdateInventoryItemByID(id, body); err != nil {
+	if status, err := h.Service.UpdateInventoryItemByID(id, body, userFromRequest(r)); err != nil {
 		h.LoggerERROR.Println(err)
 		h.respondWithError(w, status, err.Error())
 		return
@@ -162,7 +207,7 @@ func (h *CustomHandler) deleteInventoryByID(w http.ResponseWriter, r *http.Reque
 	h.LoggerINFO.Printf("deleteInventoryByID - Deleting inventory item with ID: %s", id)
 
 	// Удаляем элемент через сервис
-	if status, err := h.Service.DeleteInventoryItemByID(id); err != nil {
+	if status, err := h.Service.DeleteInventoryItemByID(id, userFromRequest(r)); err != nil {
 		h.LoggerERROR.Println(err)
 		h.respondWithError(w, status, err.Error())
 		return
@@ -172,3 +217,59 @@ func (h *CustomHandler) deleteInventoryByID(w http.ResponseWriter, r *http.Reque
 	w.Write([]byte("Inventory item deleted successfully"))
 	h.LoggerINFO.Printf("deleteInventoryByID - Inventory item with ID %s deleted successfully", id)
 }
+
+// Обработчик относительного изменения остатка ингредиента
+func (h *CustomHandler) AdjustInventoryHandler(w http.ResponseWriter, r *http.Request) {
+	h.LoggerINFO.Printf("AdjustInventoryHandler - %s request received", r.Method)
+
+	if r.Method != http.MethodPost {
+		h.respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		h.LoggerERROR.Printf("AdjustInventoryHandler - Method %s not allowed", r.Method)
+		return
+	}
+
+	id := r.PathValue("id")
+
+	body, ok := h.readJSONBody(w, r)
+	if !ok {
+		return
+	}
+
+	// Изменяем остаток через сервис
+	data, status, err := h.Service.AdjustInventoryItem(id, body, userFromRequest(r))
+	if err != nil {
+		h.LoggerERROR.Println(err)
+		h.respondWithError(w, status, err.Error())
+		return
+	}
+
+	h.LoggerINFO.Printf("AdjustInventoryHandler - Inventory item with ID %s adjusted successfully", id)
+	h.respondWithJSON(w, status, data)
+}
+
+// Обработчик приемки поставки
+func (h *CustomHandler) ReceiveInventoryHandler(w http.ResponseWriter, r *http.Request) {
+	h.LoggerINFO.Printf("ReceiveInventoryHandler - %s request received", r.Method)
+
+	if r.Method != http.MethodPost {
+		h.respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		h.LoggerERROR.Printf("ReceiveInventoryHandler - Method %s not allowed", r.Method)
+		return
+	}
+
+	body, ok := h.readJSONBody(w, r)
+	if !ok {
+		return
+	}
+
+	// Приходуем поставку через сервис
+	data, status, err := h.Service.ReceiveInventory(body, userFromRequest(r))
+	if err != nil {
+		h.LoggerERROR.Println(err)
+		h.respondWithError(w, status, err.Error())
+		return
+	}
+
+	h.LoggerINFO.Println("ReceiveInventoryHandler - Delivery received successfully")
+	h.respondWithJSON(w, status, data)
+}
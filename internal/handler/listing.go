@@ -0,0 +1,144 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"hot-coffee/internal/domain"
+)
+
+// Наибольший размер страницы списка
+const maxPerPage = 100
+
+// parseListOptions разбирает параметры sort, order (asc или desc), page и per_page.
+// Без per_page список выводится целиком.
+func parseListOptions(r *http.Request) (domain.ListOptions, error) {
+	query := r.URL.Query()
+	options := domain.ListOptions{Sort: query.Get("sort"), Page: 1}
+
+	switch order := query.Get("order"); order {
+	case "", "asc":
+	case "desc":
+		options.Desc = true
+	default:
+		return options, fmt.Errorf("invalid order: %q, expected asc or desc", order)
+	}
+
+	if value := query.Get("page"); value != "" {
+		page, err := strconv.Atoi(value)
+		if err != nil || page < 1 {
+			return options, fmt.Errorf("invalid page: %q", value)
+		}
+		options.Page = page
+	}
+
+	if value := query.Get("per_page"); value != "" {
+		perPage, err := strconv.Atoi(value)
+		if err != nil || perPage < 1 || perPage > maxPerPage {
+			return options, fmt.Errorf("invalid per_page: %q, expected 1..%d", value, maxPerPage)
+		}
+		options.PerPage = perPage
+	} else if query.Has("page") {
+		return options, fmt.Errorf("page requires per_page")
+	}
+	return options, nil
+}
+
+// setPaginationHeaders записывает общее количество записей в X-Total-Count
+// и ссылки на соседние страницы в заголовок Link
+func setPaginationHeaders(w http.ResponseWriter, r *http.Request, info domain.PageInfo) {
+	w.Header().Set("X-Total-Count", strconv.Itoa(info.Total))
+	if info.PerPage <= 0 {
+		return
+	}
+
+	links := make([]string, 0, 4)
+	addLink := func(page int, rel string) {
+		query := r.URL.Query()
+		query.Set("page", strconv.Itoa(page))
+		links = append(links, fmt.Sprintf("<%s?%s>; rel=%q", r.URL.Path, query.Encode(), rel))
+	}
+
+	addLink(1, "first")
+	if info.Page > 1 {
+		addLink(min(info.Page-1, info.Pages()), "prev")
+	}
+	if info.Page < info.Pages() {
+		addLink(info.Page+1, "next")
+	}
+	addLink(info.Pages(), "last")
+	w.Header().Set("Link", strings.Join(links, ", "))
+}
+
+// parseOrderFilter разбирает фильтры списка заказов: status, customer, customer_id, product_id и период
+func parseOrderFilter(r *http.Request) (domain.OrderFilter, error) {
+	query := r.URL.Query()
+	filter := domain.OrderFilter{
+		Status:       domain.OrderStatus(query.Get("status")),
+		CustomerName: query.Get("customer"),
+		CustomerID:   query.Get("customer_id"),
+		ProductID:    query.Get("product_id"),
+	}
+	if filter.Status != "" && !filter.Status.IsValid() {
+		return filter, fmt.Errorf("invalid status: %q", filter.Status)
+	}
+
+	from, to, err := parseDateRange(r)
+	if err != nil {
+		return filter, err
+	}
+	filter.From, filter.To = from, to
+	return filter, nil
+}
+
+// parseMenuFilter разбирает фильтры меню: q, ingredient_id, min_price и max_price
+func parseMenuFilter(r *http.Request) (domain.MenuFilter, error) {
+	query := r.URL.Query()
+	filter := domain.MenuFilter{
+		Search:       query.Get("q"),
+		IngredientID: query.Get("ingredient_id"),
+	}
+
+	var err error
+	if filter.MinPrice, err = parsePrice(query.Get("min_price")); err != nil {
+		return filter, fmt.Errorf("invalid min_price: %w", err)
+	}
+	if filter.MaxPrice, err = parsePrice(query.Get("max_price")); err != nil {
+		return filter, fmt.Errorf("invalid max_price: %w", err)
+	}
+	if filter.MaxPrice > 0 && filter.MinPrice > filter.MaxPrice {
+		return filter, fmt.Errorf("min_price must not exceed max_price")
+	}
+	return filter, nil
+}
+
+// parseInventoryFilter разбирает фильтры инвентаря: q, unit и low_stock
+func parseInventoryFilter(r *http.Request) (domain.InventoryFilter, error) {
+	query := r.URL.Query()
+	filter := domain.InventoryFilter{
+		Search: query.Get("q"),
+		Unit:   query.Get("unit"),
+	}
+
+	if value := query.Get("low_stock"); value != "" {
+		lowStock, err := strconv.ParseBool(value)
+		if err != nil {
+			return filter, fmt.Errorf("invalid low_stock: %q", value)
+		}
+		filter.LowStock = lowStock
+	}
+	return filter, nil
+}
+
+func parsePrice(value string) (float64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	price, err := strconv.ParseFloat(value, 64)
+	if err != nil || price < 0 {
+		return 0, fmt.Errorf("%q is not a valid price", value)
+	}
+	return price, nil
+}
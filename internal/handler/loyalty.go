@@ -0,0 +1,104 @@
+package handler
+
+import "net/http"
+
+// LoyaltyRuleHandler обрабатывает запросы для работы с правилами начисления баллов (получение всех, добавление нового)
+func (h *CustomHandler) LoyaltyRuleHandler(w http.ResponseWriter, r *http.Request) {
+	h.LoggerINFO.Printf("LoyaltyRuleHandler - %s request received", r.Method)
+
+	switch r.Method {
+	case http.MethodGet:
+		h.respondWithService(w, h.Service.GetAllLoyaltyRules)
+	case http.MethodPost:
+		h.saveWithService(w, r, "addLoyaltyRule", h.Service.AddLoyaltyRule)
+	default:
+		h.LoggerERROR.Printf("LoyaltyRuleHandler - Method %s not allowed", r.Method)
+		h.respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// LoyaltyRuleByIDHandler обрабатывает запросы для работы с правилом начисления по ID (обновление, удаление)
+func (h *CustomHandler) LoyaltyRuleByIDHandler(w http.ResponseWriter, r *http.Request) {
+	h.LoggerINFO.Printf("LoyaltyRuleByIDHandler - %s request received", r.Method)
+	id := r.PathValue("id")
+
+	switch r.Method {
+	case http.MethodPut:
+		h.saveWithService(w, r, "updateLoyaltyRule", func(data []byte) (int, error) {
+			return h.Service.UpdateLoyaltyRuleByID(id, data)
+		})
+	case http.MethodDelete:
+		h.deleteWithService(w, "deleteLoyaltyRule", id, h.Service.DeleteLoyaltyRuleByID)
+	default:
+		h.LoggerERROR.Printf("LoyaltyRuleByIDHandler - Method %s not allowed", r.Method)
+		h.respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// RewardHandler обрабатывает запросы для работы с наградами (получение всех, добавление новой)
+func (h *CustomHandler) RewardHandler(w http.ResponseWriter, r *http.Request) {
+	h.LoggerINFO.Printf("RewardHandler - %s request received", r.Method)
+
+	switch r.Method {
+	case http.MethodGet:
+		h.respondWithService(w, h.Service.GetAllRewards)
+	case http.MethodPost:
+		h.saveWithService(w, r, "addReward", h.Service.AddReward)
+	default:
+		h.LoggerERROR.Printf("RewardHandler - Method %s not allowed", r.Method)
+		h.respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// RewardByIDHandler обрабатывает запросы для работы с наградой по ID (обновление, удаление)
+func (h *CustomHandler) RewardByIDHandler(w http.ResponseWriter, r *http.Request) {
+	h.LoggerINFO.Printf("RewardByIDHandler - %s request received", r.Method)
+	id := r.PathValue("id")
+
+	switch r.Method {
+	case http.MethodPut:
+		h.saveWithService(w, r, "updateReward", func(data []byte) (int, error) {
+			return h.Service.UpdateRewardByID(id, data)
+		})
+	case http.MethodDelete:
+		h.deleteWithService(w, "deleteReward", id, h.Service.DeleteRewardByID)
+	default:
+		h.LoggerERROR.Printf("RewardByIDHandler - Method %s not allowed", r.Method)
+		h.respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// LoyaltyAccountHandler обрабатывает запрос баланса и журнала баллов клиента
+func (h *CustomHandler) LoyaltyAccountHandler(w http.ResponseWriter, r *http.Request) {
+	h.LoggerINFO.Printf("LoyaltyAccountHandler - %s request received", r.Method)
+
+	switch r.Method {
+	case http.MethodGet:
+		id := r.PathValue("id")
+		h.respondWithService(w, func() ([]byte, int, error) { return h.Service.GetLoyaltyAccount(id) })
+	default:
+		h.LoggerERROR.Printf("LoyaltyAccountHandler - Method %s not allowed", r.Method)
+		h.respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// AdjustLoyaltyHandler обрабатывает ручное начисление или списание баллов клиента
+func (h *CustomHandler) AdjustLoyaltyHandler(w http.ResponseWriter, r *http.Request) {
+	h.LoggerINFO.Printf("AdjustLoyaltyHandler - %s request received", r.Method)
+
+	if r.Method != http.MethodPost {
+		h.LoggerERROR.Printf("AdjustLoyaltyHandler - Method %s not allowed", r.Method)
+		h.respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	body, ok := h.readJSONBody(w, r)
+	if !ok {
+		return
+	}
+
+	id := r.PathValue("id")
+	h.respondWithService(w, func() ([]byte, int, error) {
+		return h.Service.AdjustLoyaltyPoints(id, body, userFromRequest(r))
+	})
+}
@@ -3,6 +3,8 @@ package handler
 import (
 	"io"
 	"net/http"
+
+	"hot-coffee/internal/export"
 )
 
 // MenuHandler обрабатывает запросы для работы с меню (создание, получение всех элементов)
@@ -37,23 +39,46 @@ func (h *CustomHandler) MenuByIDHandler(w http.ResponseWriter, r *http.Request)
 	}
 }
 
-// getAllMenu получает все элементы меню из базы данных
+// getAllMenu получает элементы меню с фильтрами, сортировкой и постраничным выводом
 func (h *CustomHandler) getAllMenu(w http.ResponseWriter, r *http.Request) {
-	if r.Header.Get("Content-Type") != "application/json" {
+	// Разбираем формат ответа
+	format, err := parseExportFormat(r)
+	if err != nil {
+		h.respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if format == export.FormatJSON && r.Header.Get("Content-Type") != "application/json" {
 		h.respondWithError(w, http.StatusUnsupportedMediaType, "Content-Type must be application/json")
 		return
 	}
 
 	h.LoggerINFO.Println("getAllMenu - Fetching all menu items")
 
-	// Вызов сервиса для получения всех элементов меню
-	data, status, err := h.Service.GetAllMenuItems()
+	// Разбираем фильтры, сортировку и страницу
+	filter, err := parseMenuFilter(r)
+	if err != nil {
+		h.respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	options, err := parseListOptions(r)
+	if err != nil {
+		h.respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Вызов сервиса для получения элементов меню
+	data, info, status, err := h.Service.GetAllMenuItems(filter, options)
 	if err != nil {
 		h.LoggerERROR.Println(err)
 		h.respondWithError(w, status, err.Error())
 		return
 	}
 
+	setPaginationHeaders(w, r, info)
+	if format != export.FormatJSON {
+		respondWithExportedList(h, w, format, "menu", data, export.MenuItems)
+		return
+	}
 	w.Header().Set("Content-Type", "application/json")
 	_, err = w.Write(data)
 	if err != nil {
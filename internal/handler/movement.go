@@ -0,0 +1,117 @@
+package handler
+
+import "net/http"
+
+// Обработчик получения движений по ингредиенту
+func (h *CustomHandler) InventoryMovementsHandler(w http.ResponseWriter, r *http.Request) {
+	h.LoggerINFO.Printf("InventoryMovementsHandler - %s request received", r.Method)
+
+	if r.Method != http.MethodGet {
+		h.respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		h.LoggerERROR.Printf("InventoryMovementsHandler - Method %s not allowed", r.Method)
+		return
+	}
+
+	from, to, err := parseDateRange(r)
+	if err != nil {
+		h.respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	id := r.PathValue("id")
+	data, status, err := h.Service.GetInventoryMovements(id, from, to)
+	if err != nil {
+		h.LoggerERROR.Println(err)
+		h.respondWithError(w, status, err.Error())
+		return
+	}
+
+	h.respondWithJSON(w, status, data)
+}
+
+// Обработчик отчета о расхождениях между остатками и журналом движений
+func (h *CustomHandler) StockDriftHandler(w http.ResponseWriter, r *http.Request) {
+	h.LoggerINFO.Printf("StockDriftHandler - %s request received", r.Method)
+
+	if r.Method != http.MethodGet {
+		h.respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		h.LoggerERROR.Printf("StockDriftHandler - Method %s not allowed", r.Method)
+		return
+	}
+
+	data, status, err := h.Service.GetStockDrift()
+	if err != nil {
+		h.LoggerERROR.Println(err)
+		h.respondWithError(w, status, err.Error())
+		return
+	}
+
+	h.respondWithJSON(w, status, data)
+}
+
+// Обработчик пересчета остатков по журналу движений
+func (h *CustomHandler) RebuildInventoryHandler(w http.ResponseWriter, r *http.Request) {
+	h.LoggerINFO.Printf("RebuildInventoryHandler - %s request received", r.Method)
+
+	if r.Method != http.MethodPost {
+		h.respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		h.LoggerERROR.Printf("RebuildInventoryHandler - Method %s not allowed", r.Method)
+		return
+	}
+
+	data, status, err := h.Service.RebuildInventoryFromLedger()
+	if err != nil {
+		h.LoggerERROR.Println(err)
+		h.respondWithError(w, status, err.Error())
+		return
+	}
+
+	h.LoggerINFO.Println("RebuildInventoryHandler - Inventory rebuilt from ledger")
+	h.respondWithJSON(w, status, data)
+}
+
+// Обработчик получения партий ингредиента
+func (h *CustomHandler) InventoryLotsHandler(w http.ResponseWriter, r *http.Request) {
+	h.LoggerINFO.Printf("InventoryLotsHandler - %s request received", r.Method)
+
+	if r.Method != http.MethodGet {
+		h.respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		h.LoggerERROR.Printf("InventoryLotsHandler - Method %s not allowed", r.Method)
+		return
+	}
+
+	data, status, err := h.Service.GetInventoryLots(r.PathValue("id"))
+	if err != nil {
+		h.LoggerERROR.Println(err)
+		h.respondWithError(w, status, err.Error())
+		return
+	}
+
+	h.respondWithJSON(w, status, data)
+}
+
+// Обработчик получения партий с истекающим сроком годности
+func (h *CustomHandler) ExpiringLotsHandler(w http.ResponseWriter, r *http.Request) {
+	h.LoggerINFO.Printf("ExpiringLotsHandler - %s request received", r.Method)
+
+	if r.Method != http.MethodGet {
+		h.respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		h.LoggerERROR.Printf("ExpiringLotsHandler - Method %s not allowed", r.Method)
+		return
+	}
+
+	within, err := parseWithin(r.URL.Query().Get("within"))
+	if err != nil {
+		h.respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	data, status, err := h.Service.GetExpiringLots(within)
+	if err != nil {
+		h.LoggerERROR.Println(err)
+		h.respondWithError(w, status, err.Error())
+		return
+	}
+
+	h.respondWithJSON(w, status, data)
+}
@@ -3,6 +3,8 @@ package handler
 import (
 	"io"
 	"net/http"
+
+	"hot-coffee/internal/export"
 )
 
 // OrderHandler обрабатывает запросы для работы с заказами (получение всех, добавление нового)
@@ -41,19 +43,69 @@ func (h *CustomHandler) CloseOrderHandler(w http.ResponseWriter, r *http.Request
 	}
 }
 
-// GetAllOrders получает все заказы
+// CancelOrderHandler обрабатывает запрос для отмены заказа по ID
+func (h *CustomHandler) CancelOrderHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		h.CancelOrderByID(w, r)
+	default:
+		h.respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// RefundOrderHandler обрабатывает запрос для возврата завершенного заказа по ID
+func (h *CustomHandler) RefundOrderHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		body, ok := h.readJSONBody(w, r)
+		if !ok {
+			return
+		}
+		id := r.PathValue("id")
+		h.respondWithService(w, func() ([]byte, int, error) {
+			return h.Service.RefundOrderByID(id, body, userFromRequest(r))
+		})
+	default:
+		h.respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// GetAllOrders получает заказы с фильтрами, сортировкой и постраничным выводом
 func (h *CustomHandler) GetAllOrders(w http.ResponseWriter, r *http.Request) {
-	if r.Header.Get("Content-Type") != "application/json" {
+	// Разбираем формат ответа
+	format, err := parseExportFormat(r)
+	if err != nil {
+		h.respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if format == export.FormatJSON && r.Header.Get("Content-Type") != "application/json" {
 		h.respondWithError(w, http.StatusUnsupportedMediaType, "Content-Type must be application/json")
 		return
 	}
 
-	// Получаем все заказы через сервис
-	data, status, err := h.Service.GetAllOrders()
+	// Разбираем фильтры, сортировку и страницу
+	filter, err := parseOrderFilter(r)
+	if err != nil {
+		h.respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	options, err := parseListOptions(r)
+	if err != nil {
+		h.respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Получаем заказы через сервис
+	data, info, status, err := h.Service.GetAllOrders(filter, options)
 	if err != nil {
 		h.respondWithError(w, status, err.Error())
 		return
 	}
+	setPaginationHeaders(w, r, info)
+	if format != export.FormatJSON {
+		respondWithExportedList(h, w, format, "orders", data, export.Orders)
+		return
+	}
 	h.respondWithJSON(w, status, data)
 }
 
@@ -149,7 +201,26 @@ func (h *CustomHandler) CloseOrderByID(w http.ResponseWriter, r *http.Request) {
 	id := r.PathValue("id")
 
 	// Закрываем заказ через сервис
-	status, err := h.Service.CloseOrderByID(id)
+	status, err := h.Service.CloseOrderByID(id, userFromRequest(r))
+	if err != nil {
+		h.respondWithError(w, status, err.Error())
+		return
+	}
+
+	h.respondWithJSON(w, status, nil)
+}
+
+// CancelOrderByID отменяет заказ по его ID
+func (h *CustomHandler) CancelOrderByID(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Content-Type") != "application/json" {
+		h.respondWithError(w, http.StatusUnsupportedMediaType, "Content-Type must be application/json")
+		return
+	}
+
+	id := r.PathValue("id")
+
+	// Отменяем заказ через сервис
+	status, err := h.Service.CancelOrderByID(id)
 	if err != nil {
 		h.respondWithError(w, status, err.Error())
 		return
@@ -0,0 +1,41 @@
+package handler
+
+import "net/http"
+
+// OrderPaymentHandler обрабатывает запросы для работы с оплатами заказа (получение расчета, добавление оплаты)
+func (h *CustomHandler) OrderPaymentHandler(w http.ResponseWriter, r *http.Request) {
+	h.LoggerINFO.Printf("OrderPaymentHandler - %s request received", r.Method)
+	id := r.PathValue("id")
+
+	switch r.Method {
+	case http.MethodGet:
+		h.respondWithService(w, func() ([]byte, int, error) { return h.Service.GetOrderPayments(id) })
+	case http.MethodPost:
+		body, ok := h.readJSONBody(w, r)
+		if !ok {
+			return
+		}
+		h.respondWithService(w, func() ([]byte, int, error) {
+			return h.Service.AddPayment(id, body, userFromRequest(r))
+		})
+	default:
+		h.LoggerERROR.Printf("OrderPaymentHandler - Method %s not allowed", r.Method)
+		h.respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// OrderPaymentByIDHandler обрабатывает отмену оплаты заказа
+func (h *CustomHandler) OrderPaymentByIDHandler(w http.ResponseWriter, r *http.Request) {
+	h.LoggerINFO.Printf("OrderPaymentByIDHandler - %s request received", r.Method)
+
+	if r.Method != http.MethodDelete {
+		h.LoggerERROR.Printf("OrderPaymentByIDHandler - Method %s not allowed", r.Method)
+		h.respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	orderID := r.PathValue("id")
+	h.deleteWithService(w, "voidPayment", r.PathValue("payment_id"), func(paymentID string) (int, error) {
+		return h.Service.VoidPayment(orderID, paymentID)
+	})
+}
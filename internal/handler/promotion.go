@@ -0,0 +1,38 @@
+package handler
+
+import "net/http"
+
+// PromotionHandler обрабатывает запросы для работы с акциями (получение всех, добавление новой)
+func (h *CustomHandler) PromotionHandler(w http.ResponseWriter, r *http.Request) {
+	h.LoggerINFO.Printf("PromotionHandler - %s request received", r.Method)
+
+	switch r.Method {
+	case http.MethodGet:
+		h.respondWithService(w, h.Service.GetAllPromotions)
+	case http.MethodPost:
+		h.saveWithService(w, r, "addPromotion", h.Service.AddPromotion)
+	default:
+		h.LoggerERROR.Printf("PromotionHandler - Method %s not allowed", r.Method)
+		h.respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// PromotionByIDHandler обрабатывает запросы для работы с акцией по ID (получение, обновление, удаление)
+func (h *CustomHandler) PromotionByIDHandler(w http.ResponseWriter, r *http.Request) {
+	h.LoggerINFO.Printf("PromotionByIDHandler - %s request received", r.Method)
+	id := r.PathValue("id")
+
+	switch r.Method {
+	case http.MethodGet:
+		h.respondWithService(w, func() ([]byte, int, error) { return h.Service.GetPromotionByID(id) })
+	case http.MethodPut:
+		h.saveWithService(w, r, "updatePromotion", func(data []byte) (int, error) {
+			return h.Service.UpdatePromotionByID(id, data)
+		})
+	case http.MethodDelete:
+		h.deleteWithService(w, "deletePromotion", id, h.Service.DeletePromotionByID)
+	default:
+		h.LoggerERROR.Printf("PromotionByIDHandler - Method %s not allowed", r.Method)
+		h.respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
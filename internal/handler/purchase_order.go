@@ -0,0 +1,119 @@
+package handler
+
+import "net/http"
+
+// PurchaseOrderHandler обрабатывает запросы для работы с заказами поставщикам (получение всех, создание)
+func (h *CustomHandler) PurchaseOrderHandler(w http.ResponseWriter, r *http.Request) {
+	h.LoggerINFO.Printf("PurchaseOrderHandler - %s request received", r.Method)
+
+	switch r.Method {
+	case http.MethodGet:
+		data, status, err := h.Service.GetAllPurchaseOrders()
+		if err != nil {
+			h.LoggerERROR.Println(err)
+			h.respondWithError(w, status, err.Error())
+			return
+		}
+		h.respondWithJSON(w, status, data)
+	case http.MethodPost:
+		body, ok := h.readJSONBody(w, r)
+		if !ok {
+			return
+		}
+		data, status, err := h.Service.CreatePurchaseOrder(body)
+		if err != nil {
+			h.LoggerERROR.Println(err)
+			h.respondWithError(w, status, err.Error())
+			return
+		}
+		h.LoggerINFO.Println("PurchaseOrderHandler - Purchase order created successfully")
+		h.respondWithJSON(w, status, data)
+	default:
+		h.LoggerERROR.Printf("PurchaseOrderHandler - Method %s not allowed", r.Method)
+		h.respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// PurchaseOrderByIDHandler возвращает заказ поставщику по ID
+func (h *CustomHandler) PurchaseOrderByIDHandler(w http.ResponseWriter, r *http.Request) {
+	h.LoggerINFO.Printf("PurchaseOrderByIDHandler - %s request received", r.Method)
+
+	if r.Method != http.MethodGet {
+		h.LoggerERROR.Printf("PurchaseOrderByIDHandler - Method %s not allowed", r.Method)
+		h.respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	data, status, err := h.Service.GetPurchaseOrderByID(r.PathValue("id"))
+	if err != nil {
+		h.LoggerERROR.Println(err)
+		h.respondWithError(w, status, err.Error())
+		return
+	}
+	h.respondWithJSON(w, status, data)
+}
+
+// GeneratePurchaseOrdersHandler создает черновики заказов для ингредиентов с низким остатком
+func (h *CustomHandler) GeneratePurchaseOrdersHandler(w http.ResponseWriter, r *http.Request) {
+	h.LoggerINFO.Printf("GeneratePurchaseOrdersHandler - %s request received", r.Method)
+
+	if r.Method != http.MethodPost {
+		h.LoggerERROR.Printf("GeneratePurchaseOrdersHandler - Method %s not allowed", r.Method)
+		h.respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	data, status, err := h.Service.GeneratePurchaseOrders()
+	if err != nil {
+		h.LoggerERROR.Println(err)
+		h.respondWithError(w, status, err.Error())
+		return
+	}
+	h.respondWithJSON(w, status, data)
+}
+
+// SendPurchaseOrderHandler отмечает заказ как отправленный поставщику
+func (h *CustomHandler) SendPurchaseOrderHandler(w http.ResponseWriter, r *http.Request) {
+	h.LoggerINFO.Printf("SendPurchaseOrderHandler - %s request received", r.Method)
+
+	if r.Method != http.MethodPost {
+		h.LoggerERROR.Printf("SendPurchaseOrderHandler - Method %s not allowed", r.Method)
+		h.respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	data, status, err := h.Service.SendPurchaseOrder(r.PathValue("id"))
+	if err != nil {
+		h.LoggerERROR.Println(err)
+		h.respondWithError(w, status, err.Error())
+		return
+	}
+	h.respondWithJSON(w, status, data)
+}
+
+// ReceivePurchaseOrderHandler приходует товар по заказу поставщику
+func (h *CustomHandler) ReceivePurchaseOrderHandler(w http.ResponseWriter, r *http.Request) {
+	h.LoggerINFO.Printf("ReceivePurchaseOrderHandler - %s request received", r.Method)
+
+	if r.Method != http.MethodPost {
+		h.LoggerERROR.Printf("ReceivePurchaseOrderHandler - Method %s not allowed", r.Method)
+		h.respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	body, ok := h.readJSONBody(w, r)
+	if !ok {
+		return
+	}
+
+	id := r.PathValue("id")
+	data, status, err := h.Service.ReceivePurchaseOrder(id, body, userFromRequest(r))
+	if err != nil {
+		h.LoggerERROR.Println(err)
+		h.respondWithError(w, status, err.Error())
+		return
+	}
+
+	h.LoggerINFO.Printf("ReceivePurchaseOrderHandler - Purchase order %s received", id)
+	h.respondWithJSON(w, status, data)
+}
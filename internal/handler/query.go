@@ -0,0 +1,223 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"hot-coffee/internal/domain"
+)
+
+// Формат даты в параметрах запроса
+const dateLayout = "2006-01-02"
+
+// parseDateRange разбирает параметры start_date и end_date.
+// Даты принимаются в формате YYYY-MM-DD или RFC3339; end_date в формате даты включает весь день.
+// Даты без часового пояса относятся к поясу из параметра timezone (по умолчанию местному).
+func parseDateRange(r *http.Request) (time.Time, time.Time, error) {
+	loc, err := parseLocation(r)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	from, err := parseDate(r.URL.Query().Get("start_date"), false, loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid start_date: %w", err)
+	}
+
+	to, err := parseDate(r.URL.Query().Get("end_date"), true, loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid end_date: %w", err)
+	}
+
+	if !from.IsZero() && !to.IsZero() && !from.Before(to) {
+		return time.Time{}, time.Time{}, fmt.Errorf("start_date must be before end_date")
+	}
+	return from, to, nil
+}
+
+func parseDate(value string, endOfDay bool, loc *time.Location) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	t, err := time.ParseInLocation(dateLayout, value, loc)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("expected YYYY-MM-DD or RFC3339, got %q", value)
+	}
+	if endOfDay {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t, nil
+}
+
+// parseLocation разбирает параметр timezone (имя из базы IANA, например Asia/Almaty)
+func parseLocation(r *http.Request) (*time.Location, error) {
+	name := r.URL.Query().Get("timezone")
+	if name == "" {
+		return time.Local, nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone: %q", name)
+	}
+	return loc, nil
+}
+
+// parseReportFilter разбирает общие параметры отчетов: период, часовой пояс, group_by и compare
+func parseReportFilter(r *http.Request) (domain.ReportFilter, error) {
+	var filter domain.ReportFilter
+
+	loc, err := parseLocation(r)
+	if err != nil {
+		return filter, err
+	}
+	from, to, err := parseDateRange(r)
+	if err != nil {
+		return filter, err
+	}
+	filter.From, filter.To, filter.Location = from, to, loc
+
+	if groupBy := r.URL.Query().Get("group_by"); groupBy != "" {
+		filter.GroupBy = domain.ReportGroupBy(groupBy)
+		if !filter.GroupBy.IsValid() {
+			return filter, fmt.Errorf("invalid group_by: %q, expected hour, day, week or month", groupBy)
+		}
+		// Без end_date период длится до текущего момента
+		if !from.IsZero() {
+			end := to
+			if end.IsZero() {
+				end = time.Now()
+			}
+			if filter.GroupBy.Periods(from, end, loc) > domain.MaxReportPeriods {
+				return filter, fmt.Errorf("range is too large for group_by=%s: at most %d periods", groupBy, domain.MaxReportPeriods)
+			}
+		}
+	}
+
+	if compare := r.URL.Query().Get("compare"); compare != "" {
+		filter.Compare = domain.ReportCompare(compare)
+		if !filter.Compare.IsValid() {
+			return filter, fmt.Errorf("invalid compare: %q, expected previous_period or previous_year", compare)
+		}
+		if from.IsZero() || to.IsZero() {
+			return filter, fmt.Errorf("compare requires both start_date and end_date")
+		}
+	}
+	return filter, nil
+}
+
+// Период по умолчанию для отчета об истекающих партиях
+const defaultExpiringWithin = 48 * time.Hour
+
+// parseWithin разбирает параметр within: длительность Go (48h, 90m) или количество дней (2d)
+func parseWithin(value string) (time.Duration, error) {
+	if value == "" {
+		return defaultExpiringWithin, nil
+	}
+
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid within: %q", value)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+
+	within, err := time.ParseDuration(value)
+	if err != nil || within < 0 {
+		return 0, fmt.Errorf("invalid within: %q", value)
+	}
+	return within, nil
+}
+
+// parsePopularItemsOptions разбирает параметры sort, view и limit отчета о популярных позициях
+func parsePopularItemsOptions(r *http.Request) (domain.PopularItemsOptions, error) {
+	options := domain.PopularItemsOptions{Sort: domain.SortByQuantity, View: domain.ViewPopular}
+
+	if value := r.URL.Query().Get("sort"); value != "" {
+		options.Sort = domain.PopularItemsSort(value)
+		if !options.Sort.IsValid() {
+			return options, fmt.Errorf("invalid sort: %q, expected quantity or revenue", value)
+		}
+	}
+
+	if value := r.URL.Query().Get("view"); value != "" {
+		options.View = domain.PopularItemsView(value)
+		if !options.View.IsValid() {
+			return options, fmt.Errorf("invalid view: %q, expected popular, least or unsold", value)
+		}
+	}
+
+	if value := r.URL.Query().Get("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit <= 0 {
+			return options, fmt.Errorf("invalid limit: %q", value)
+		}
+		options.Limit = limit
+	}
+	return options, nil
+}
+
+// Параметры прогноза по умолчанию: история за четыре недели и прогноз на неделю
+const (
+	defaultLookbackDays = 28
+	defaultHorizonDays  = 7
+)
+
+// parseDays разбирает неотрицательное количество дней из параметра запроса
+func parseDays(r *http.Request, name string, fallback int) (int, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return fallback, nil
+	}
+	days, err := strconv.Atoi(value)
+	if err != nil || days < 0 {
+		return 0, fmt.Errorf("invalid %s: %q", name, value)
+	}
+	return days, nil
+}
+
+// parseBasketOptions разбирает параметры min_support (доля заказов от 0 до 1) и limit анализа корзины
+func parseBasketOptions(r *http.Request) (domain.BasketOptions, error) {
+	var options domain.BasketOptions
+
+	if value := r.URL.Query().Get("min_support"); value != "" {
+		minSupport, err := strconv.ParseFloat(value, 64)
+		if err != nil || minSupport < 0 || minSupport > 1 {
+			return options, fmt.Errorf("invalid min_support: %q, expected a number from 0 to 1", value)
+		}
+		options.MinSupport = minSupport
+	}
+
+	if value := r.URL.Query().Get("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit <= 0 {
+			return options, fmt.Errorf("invalid limit: %q", value)
+		}
+		options.Limit = limit
+	}
+	return options, nil
+}
+
+// Порог необъяснимого расхождения расхода ингредиентов по умолчанию, в процентах
+const defaultVarianceThreshold = 5.0
+
+// parseVarianceThreshold разбирает параметр variance_threshold в процентах
+func parseVarianceThreshold(r *http.Request) (float64, error) {
+	value := r.URL.Query().Get("variance_threshold")
+	if value == "" {
+		return defaultVarianceThreshold, nil
+	}
+	threshold, err := strconv.ParseFloat(value, 64)
+	if err != nil || threshold < 0 {
+		return 0, fmt.Errorf("invalid variance_threshold: %q", value)
+	}
+	return threshold, nil
+}
@@ -9,18 +9,82 @@ func (h *CustomHandler) Routing() *http.ServeMux {
 	router.HandleFunc("/order", h.OrderHandler)
 	router.HandleFunc("/order/{id}", h.OrderByIDHandler)
 	router.HandleFunc("/order/{id}/close", h.CloseOrderHandler)
+	router.HandleFunc("/order/{id}/cancel", h.CancelOrderHandler)
+	router.HandleFunc("/order/{id}/refund", h.RefundOrderHandler)
+	router.HandleFunc("/order/{id}/payments", h.OrderPaymentHandler)
+	router.HandleFunc("/order/{id}/payments/{payment_id}", h.OrderPaymentByIDHandler)
 
 	// Menu
 	router.HandleFunc("/menu", h.MenuHandler)
 	router.HandleFunc("/menu/{id}", h.MenuByIDHandler)
+	router.HandleFunc("/menu/{id}/waste", h.MenuWasteHandler)
+	router.HandleFunc("/menu/import", h.MenuImportHandler)
 
 	// Inventory
 	router.HandleFunc("/inventory", h.InventoryHandler)
 	router.HandleFunc("/inventory/{id}", h.InventoryByIDHandler)
+	router.HandleFunc("/inventory/low-stock", h.LowStockHandler)
+	router.HandleFunc("/inventory/{id}/movements", h.InventoryMovementsHandler)
+	router.HandleFunc("/inventory/{id}/adjust", h.AdjustInventoryHandler)
+	router.HandleFunc("/inventory/receive", h.ReceiveInventoryHandler)
+	router.HandleFunc("/inventory/import", h.InventoryImportHandler)
+	router.HandleFunc("/inventory/{id}/lots", h.InventoryLotsHandler)
+	router.HandleFunc("/inventory/expiring", h.ExpiringLotsHandler)
+	router.HandleFunc("/inventory/waste", h.InventoryWasteHandler)
+	router.HandleFunc("/inventory/ledger/drift", h.StockDriftHandler)
+	router.HandleFunc("/inventory/ledger/rebuild", h.RebuildInventoryHandler)
+
+	// Customers
+	router.HandleFunc("/customers", h.CustomerHandler)
+	router.HandleFunc("/customers/{id}", h.CustomerByIDHandler)
+	router.HandleFunc("/customers/{id}/orders", h.CustomerOrdersHandler)
+	router.HandleFunc("/customers/{id}/loyalty", h.LoyaltyAccountHandler)
+	router.HandleFunc("/customers/{id}/loyalty/adjust", h.AdjustLoyaltyHandler)
+
+	// Loyalty
+	router.HandleFunc("/loyalty/rules", h.LoyaltyRuleHandler)
+	router.HandleFunc("/loyalty/rules/{id}", h.LoyaltyRuleByIDHandler)
+	router.HandleFunc("/loyalty/rewards", h.RewardHandler)
+	router.HandleFunc("/loyalty/rewards/{id}", h.RewardByIDHandler)
+
+	// Promotions
+	router.HandleFunc("/promotions", h.PromotionHandler)
+	router.HandleFunc("/promotions/{id}", h.PromotionByIDHandler)
+
+	// Suppliers
+	router.HandleFunc("/suppliers", h.SupplierHandler)
+	router.HandleFunc("/suppliers/{id}", h.SupplierByIDHandler)
+
+	// Purchase orders
+	router.HandleFunc("/purchase-orders", h.PurchaseOrderHandler)
+	router.HandleFunc("/purchase-orders/generate", h.GeneratePurchaseOrdersHandler)
+	router.HandleFunc("/purchase-orders/{id}", h.PurchaseOrderByIDHandler)
+	router.HandleFunc("/purchase-orders/{id}/send", h.SendPurchaseOrderHandler)
+	router.HandleFunc("/purchase-orders/{id}/receive", h.ReceivePurchaseOrderHandler)
+
+	// Stock counts
+	router.HandleFunc("/stock-counts", h.StockCountHandler)
+	router.HandleFunc("/stock-counts/{id}", h.StockCountByIDHandler)
+	router.HandleFunc("/stock-counts/{id}/counts", h.SubmitStockCountHandler)
+	router.HandleFunc("/stock-counts/{id}/variance", h.StockCountVarianceHandler)
+	router.HandleFunc("/stock-counts/{id}/commit", h.CommitStockCountHandler)
+	router.HandleFunc("/stock-counts/{id}/cancel", h.CancelStockCountHandler)
+
+	// Search
+	router.HandleFunc("/search", h.SearchHandler)
 
 	// aggregation
 	router.HandleFunc("/reports/total-sales", h.GetTotalSalesHandler)
 	router.HandleFunc("/reports/popular-items", h.GetPopularItemsHandler)
+	router.HandleFunc("/reports/basket", h.GetBasketReportHandler)
+	router.HandleFunc("/reports/waste", h.GetWasteReportHandler)
+	router.HandleFunc("/reports/inventory-valuation", h.GetInventoryValuationHandler)
+	router.HandleFunc("/reports/cogs", h.GetCOGSReportHandler)
+	router.HandleFunc("/reports/ingredient-usage", h.GetIngredientUsageHandler)
+	router.HandleFunc("/reports/forecast", h.GetForecastHandler)
+	router.HandleFunc("/reports/traffic", h.GetTrafficReportHandler)
+	router.HandleFunc("/reports/daily/{date}", h.GetDailyReportHandler)
+	router.HandleFunc("/reports/daily/{date}/close", h.CloseDayHandler)
 
 	router.HandleFunc("/", h.RootHandler)
 	return router
@@ -0,0 +1,54 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Обработчик поиска по меню, заказам и инвентарю
+func (h *CustomHandler) SearchHandler(w http.ResponseWriter, r *http.Request) {
+	h.LoggerINFO.Println("SearchHandler - Received search request.")
+
+	if r.Method != http.MethodGet {
+		h.respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	query := strings.TrimSpace(r.URL.Query().Get("q"))
+	if query == "" {
+		h.respondWithError(w, http.StatusBadRequest, "query parameter q is required")
+		return
+	}
+
+	from, to, err := parseDateRange(r)
+	if err != nil {
+		h.respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	limit := 0
+	if value := r.URL.Query().Get("limit"); value != "" {
+		limit, err = strconv.Atoi(value)
+		if err != nil || limit <= 0 {
+			h.respondWithError(w, http.StatusBadRequest, "invalid limit: "+strconv.Quote(value))
+			return
+		}
+	}
+
+	results, err := h.Service.Search(query, from, to, limit)
+	if err != nil {
+		h.respondWithError(w, http.StatusInternalServerError, err.Error())
+		h.LoggerERROR.Printf("Error searching for %q: %v", query, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(results); err != nil {
+		h.LoggerERROR.Printf("SearchHandler - Error encoding response: %v", err)
+		h.respondWithError(w, http.StatusInternalServerError, "Failed to encode response")
+		return
+	}
+	h.LoggerINFO.Printf("SearchHandler - Found %d results for %q.", results.Total, query)
+}
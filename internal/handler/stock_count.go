@@ -0,0 +1,141 @@
+package handler
+
+import "net/http"
+
+// StockCountHandler обрабатывает запросы для работы с сессиями инвентаризации (получение всех, открытие новой)
+func (h *CustomHandler) StockCountHandler(w http.ResponseWriter, r *http.Request) {
+	h.LoggerINFO.Printf("StockCountHandler - %s request received", r.Method)
+
+	switch r.Method {
+	case http.MethodGet:
+		data, status, err := h.Service.GetAllStockCounts()
+		if err != nil {
+			h.LoggerERROR.Println(err)
+			h.respondWithError(w, status, err.Error())
+			return
+		}
+		h.respondWithJSON(w, status, data)
+	case http.MethodPost:
+		body, ok := h.readJSONBody(w, r)
+		if !ok {
+			return
+		}
+		data, status, err := h.Service.OpenStockCount(body, userFromRequest(r))
+		if err != nil {
+			h.LoggerERROR.Println(err)
+			h.respondWithError(w, status, err.Error())
+			return
+		}
+		h.LoggerINFO.Println("StockCountHandler - Stock count opened successfully")
+		h.respondWithJSON(w, status, data)
+	default:
+		h.LoggerERROR.Printf("StockCountHandler - Method %s not allowed", r.Method)
+		h.respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// StockCountByIDHandler возвращает сессию инвентаризации по ID
+func (h *CustomHandler) StockCountByIDHandler(w http.ResponseWriter, r *http.Request) {
+	h.LoggerINFO.Printf("StockCountByIDHandler - %s request received", r.Method)
+
+	if r.Method != http.MethodGet {
+		h.LoggerERROR.Printf("StockCountByIDHandler - Method %s not allowed", r.Method)
+		h.respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	data, status, err := h.Service.GetStockCountByID(r.PathValue("id"))
+	if err != nil {
+		h.LoggerERROR.Println(err)
+		h.respondWithError(w, status, err.Error())
+		return
+	}
+	h.respondWithJSON(w, status, data)
+}
+
+// SubmitStockCountHandler записывает посчитанные количества
+func (h *CustomHandler) SubmitStockCountHandler(w http.ResponseWriter, r *http.Request) {
+	h.LoggerINFO.Printf("SubmitStockCountHandler - %s request received", r.Method)
+
+	if r.Method != http.MethodPost {
+		h.LoggerERROR.Printf("SubmitStockCountHandler - Method %s not allowed", r.Method)
+		h.respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	body, ok := h.readJSONBody(w, r)
+	if !ok {
+		return
+	}
+
+	data, status, err := h.Service.SubmitStockCount(r.PathValue("id"), body, userFromRequest(r))
+	if err != nil {
+		h.LoggerERROR.Println(err)
+		h.respondWithError(w, status, err.Error())
+		return
+	}
+	h.respondWithJSON(w, status, data)
+}
+
+// StockCountVarianceHandler возвращает расхождения по сессии инвентаризации
+func (h *CustomHandler) StockCountVarianceHandler(w http.ResponseWriter, r *http.Request) {
+	h.LoggerINFO.Printf("StockCountVarianceHandler - %s request received", r.Method)
+
+	if r.Method != http.MethodGet {
+		h.LoggerERROR.Printf("StockCountVarianceHandler - Method %s not allowed", r.Method)
+		h.respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	data, status, err := h.Service.GetStockCountVariance(r.PathValue("id"))
+	if err != nil {
+		h.LoggerERROR.Println(err)
+		h.respondWithError(w, status, err.Error())
+		return
+	}
+	h.respondWithJSON(w, status, data)
+}
+
+// CommitStockCountHandler применяет результаты инвентаризации к остаткам
+func (h *CustomHandler) CommitStockCountHandler(w http.ResponseWriter, r *http.Request) {
+	h.LoggerINFO.Printf("CommitStockCountHandler - %s request received", r.Method)
+
+	if r.Method != http.MethodPost {
+		h.LoggerERROR.Printf("CommitStockCountHandler - Method %s not allowed", r.Method)
+		h.respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	id := r.PathValue("id")
+	data, status, err := h.Service.CommitStockCount(id, userFromRequest(r))
+	if err != nil {
+		h.LoggerERROR.Println(err)
+		h.respondWithError(w, status, err.Error())
+		return
+	}
+
+	h.LoggerINFO.Printf("CommitStockCountHandler - Stock count %s committed", id)
+	h.respondWithJSON(w, status, data)
+}
+
+// CancelStockCountHandler отменяет открытую сессию инвентаризации
+func (h *CustomHandler) CancelStockCountHandler(w http.ResponseWriter, r *http.Request) {
+	h.LoggerINFO.Printf("CancelStockCountHandler - %s request received", r.Method)
+
+	if r.Method != http.MethodPost {
+		h.LoggerERROR.Printf("CancelStockCountHandler - Method %s not allowed", r.Method)
+		h.respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	id := r.PathValue("id")
+	data, status, err := h.Service.CancelStockCount(id, userFromRequest(r))
+	if err != nil {
+		h.LoggerERROR.Println(err)
+		h.respondWithError(w, status, err.Error())
+		return
+	}
+
+	h.LoggerINFO.Printf("CancelStockCountHandler - Stock count %s cancelled", id)
+	h.respondWithJSON(w, status, data)
+}
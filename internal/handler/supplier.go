@@ -0,0 +1,112 @@
+package handler
+
+import "net/http"
+
+// SupplierHandler обрабатывает запросы для работы с поставщиками (получение всех, добавление нового)
+func (h *CustomHandler) SupplierHandler(w http.ResponseWriter, r *http.Request) {
+	h.LoggerINFO.Printf("SupplierHandler - %s request received", r.Method)
+
+	switch r.Method {
+	case http.MethodGet:
+		h.getAllSuppliers(w, r)
+	case http.MethodPost:
+		h.addSupplier(w, r)
+	default:
+		h.LoggerERROR.Printf("SupplierHandler - Method %s not allowed", r.Method)
+		h.respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// SupplierByIDHandler обрабатывает запросы для работы с поставщиком по ID (получение, обновление, удаление)
+func (h *CustomHandler) SupplierByIDHandler(w http.ResponseWriter, r *http.Request) {
+	h.LoggerINFO.Printf("SupplierByIDHandler - %s request received", r.Method)
+
+	switch r.Method {
+	case http.MethodGet:
+		h.getSupplierByID(w, r)
+	case http.MethodPut:
+		h.updateSupplierByID(w, r)
+	case http.MethodDelete:
+		h.deleteSupplierByID(w, r)
+	default:
+		h.LoggerERROR.Printf("SupplierByIDHandler - Method %s not allowed", r.Method)
+		h.respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// getAllSuppliers получает всех поставщиков
+func (h *CustomHandler) getAllSuppliers(w http.ResponseWriter, r *http.Request) {
+	data, status, err := h.Service.GetAllSuppliers()
+	if err != nil {
+		h.LoggerERROR.Println(err)
+		h.respondWithError(w, status, err.Error())
+		return
+	}
+	h.respondWithJSON(w, status, data)
+}
+
+// addSupplier добавляет нового поставщика
+func (h *CustomHandler) addSupplier(w http.ResponseWriter, r *http.Request) {
+	body, ok := h.readJSONBody(w, r)
+	if !ok {
+		return
+	}
+
+	status, err := h.Service.AddSupplier(body)
+	if err != nil {
+		h.LoggerERROR.Println("Service error:", err)
+		h.respondWithError(w, status, err.Error())
+		return
+	}
+
+	w.WriteHeader(status)
+	h.LoggerINFO.Println("addSupplier - Supplier added successfully")
+}
+
+// getSupplierByID получает поставщика по его ID
+func (h *CustomHandler) getSupplierByID(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	data, status, err := h.Service.GetSupplierByID(id)
+	if err != nil {
+		h.LoggerERROR.Println(err)
+		h.respondWithError(w, status, err.Error())
+		return
+	}
+	h.respondWithJSON(w, status, data)
+}
+
+// updateSupplierByID обновляет поставщика по его ID
+func (h *CustomHandler) updateSupplierByID(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	body, ok := h.readJSONBody(w, r)
+	if !ok {
+		return
+	}
+
+	status, err := h.Service.UpdateSupplierByID(id, body)
+	if err != nil {
+		h.LoggerERROR.Println(err)
+		h.respondWithError(w, status, err.Error())
+		return
+	}
+
+	w.WriteHeader(status)
+	h.LoggerINFO.Printf("updateSupplierByID - Supplier with ID %s updated successfully", id)
+}
+
+// deleteSupplierByID удаляет поставщика по его ID
+func (h *CustomHandler) deleteSupplierByID(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	status, err := h.Service.DeleteSupplierByID(id)
+	if err != nil {
+		h.LoggerERROR.Println(err)
+		h.respondWithError(w, status, err.Error())
+		return
+	}
+
+	w.WriteHeader(status)
+	h.LoggerINFO.Printf("deleteSupplierByID - Supplier with ID %s deleted successfully", id)
+}
@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"hot-coffee/internal/export"
+)
+
+// Обработчик отчета о загрузке по часам и дням недели
+func (h *CustomHandler) GetTrafficReportHandler(w http.ResponseWriter, r *http.Request) {
+	h.LoggerINFO.Println("GetTrafficReportHandler - Received request to get traffic report.")
+
+	format, err := parseExportFormat(r)
+	if err != nil {
+		h.respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	loc, err := parseLocation(r)
+	if err != nil {
+		h.respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	from, to, err := parseDateRange(r)
+	if err != nil {
+		h.respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	report, err := h.Service.GetTrafficReport(from, to, loc)
+	if err != nil {
+		h.respondWithError(w, http.StatusInternalServerError, err.Error())
+		h.LoggerERROR.Printf("Error getting traffic report: %v", err)
+		return
+	}
+
+	if format != export.FormatJSON {
+		h.respondWithExport(w, format, "traffic", export.TrafficReport(report))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		h.LoggerERROR.Printf("GetTrafficReportHandler - Error encoding response: %v", err)
+		h.respondWithError(w, http.StatusInternalServerError, "Failed to encode response")
+		return
+	}
+	h.LoggerINFO.Println("GetTrafficReportHandler - Successfully responded with traffic report.")
+}
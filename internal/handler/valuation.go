@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"hot-coffee/internal/export"
+)
+
+// Обработчик отчета об оценке запасов
+func (h *CustomHandler) GetInventoryValuationHandler(w http.ResponseWriter, r *http.Request) {
+	h.LoggerINFO.Println("GetInventoryValuationHandler - Received request to get inventory valuation.")
+
+	format, err := parseExportFormat(r)
+	if err != nil {
+		h.respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	from, to, err := parseDateRange(r)
+	if err != nil {
+		h.respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	report, err := h.Service.GetInventoryValuation(from, to)
+	if err != nil {
+		h.respondWithError(w, http.StatusInternalServerError, err.Error())
+		h.LoggerERROR.Printf("Error getting inventory valuation: %v", err)
+		return
+	}
+
+	if format != export.FormatJSON {
+		h.respondWithExport(w, format, "inventory-valuation", export.InventoryValuation(report))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		h.LoggerERROR.Printf("GetInventoryValuationHandler - Error encoding response: %v", err)
+		h.respondWithError(w, http.StatusInternalServerError, "Failed to encode response")
+		return
+	}
+	h.LoggerINFO.Println("GetInventoryValuationHandler - Successfully responded with inventory valuation.")
+}
+
+// Обработчик отчета о себестоимости продаж
+func (h *CustomHandler) GetCOGSReportHandler(w http.ResponseWriter, r *http.Request) {
+	h.LoggerINFO.Println("GetCOGSReportHandler - Received request to get COGS report.")
+
+	format, err := parseExportFormat(r)
+	if err != nil {
+		h.respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	from, to, err := parseDateRange(r)
+	if err != nil {
+		h.respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	report, err := h.Service.GetCOGSReport(from, to)
+	if err != nil {
+		h.respondWithError(w, http.StatusInternalServerError, err.Error())
+		h.LoggerERROR.Printf("Error getting COGS report: %v", err)
+		return
+	}
+
+	if format != export.FormatJSON {
+		h.respondWithExport(w, format, "cogs", export.COGSReport(report))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		h.LoggerERROR.Printf("GetCOGSReportHandler - Error encoding response: %v", err)
+		h.respondWithError(w, http.StatusInternalServerError, "Failed to encode response")
+		return
+	}
+	h.LoggerINFO.Println("GetCOGSReportHandler - Successfully responded with COGS report.")
+}
@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"hot-coffee/internal/export"
+)
+
+// Обработчик списания ингредиента в отходы
+func (h *CustomHandler) InventoryWasteHandler(w http.ResponseWriter, r *http.Request) {
+	h.LoggerINFO.Printf("InventoryWasteHandler - %s request received", r.Method)
+
+	if r.Method != http.MethodPost {
+		h.respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		h.LoggerERROR.Printf("InventoryWasteHandler - Method %s not allowed", r.Method)
+		return
+	}
+
+	body, ok := h.readJSONBody(w, r)
+	if !ok {
+		return
+	}
+
+	data, status, err := h.Service.WasteIngredient(body, userFromRequest(r))
+	if err != nil {
+		h.LoggerERROR.Println(err)
+		h.respondWithError(w, status, err.Error())
+		return
+	}
+
+	h.LoggerINFO.Println("InventoryWasteHandler - Waste recorded successfully")
+	h.respondWithJSON(w, status, data)
+}
+
+// Обработчик списания приготовленных позиций меню
+func (h *CustomHandler) MenuWasteHandler(w http.ResponseWriter, r *http.Request) {
+	h.LoggerINFO.Printf("MenuWasteHandler - %s request received", r.Method)
+
+	if r.Method != http.MethodPost {
+		h.respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		h.LoggerERROR.Printf("MenuWasteHandler - Method %s not allowed", r.Method)
+		return
+	}
+
+	body, ok := h.readJSONBody(w, r)
+	if !ok {
+		return
+	}
+
+	id := r.PathValue("id")
+	data, status, err := h.Service.WasteMenuItem(id, body, userFromRequest(r))
+	if err != nil {
+		h.LoggerERROR.Println(err)
+		h.respondWithError(w, status, err.Error())
+		return
+	}
+
+	h.LoggerINFO.Printf("MenuWasteHandler - Waste of menu item %s recorded successfully", id)
+	h.respondWithJSON(w, status, data)
+}
+
+// Обработчик отчета об отходах
+func (h *CustomHandler) GetWasteReportHandler(w http.ResponseWriter, r *http.Request) {
+	h.LoggerINFO.Println("GetWasteReportHandler - Received request to get waste report.")
+
+	format, err := parseExportFormat(r)
+	if err != nil {
+		h.respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	from, to, err := parseDateRange(r)
+	if err != nil {
+		h.respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	report, err := h.Service.GetWasteReport(from, to)
+	if err != nil {
+		h.respondWithError(w, http.StatusInternalServerError, err.Error())
+		h.LoggerERROR.Printf("Error getting waste report: %v", err)
+		return
+	}
+
+	if format != export.FormatJSON {
+		h.respondWithExport(w, format, "waste", export.WasteReport(report))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		h.LoggerERROR.Printf("GetWasteReportHandler - Error encoding response: %v", err)
+		h.respondWithError(w, http.StatusInternalServerError, "Failed to encode response")
+		return
+	}
+	h.LoggerINFO.Println("GetWasteReportHandler - Successfully responded with waste report.")
+}
@@ -0,0 +1,91 @@
+package notifier
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"hot-coffee/internal/domain"
+)
+
+// Интерфейс получателя уведомлений о низких остатках
+type Notifier interface {
+	Notify(alert domain.LowStockItem) error
+}
+
+// WebhookNotifier отправляет уведомления POST-запросом с JSON-телом
+type WebhookNotifier struct {
+	URL    string
+	Client *http.Client
+}
+
+func NewWebhookNotifier(url string) *WebhookNotifier {
+	return &WebhookNotifier{URL: url, Client: &http.Client{Timeout: 5 * time.Second}}
+}
+
+func (n *WebhookNotifier) Notify(alert domain.LowStockItem) error {
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return err
+	}
+
+	resp, err := n.Client.Post(n.URL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("webhook %s responded with status %d", n.URL, resp.StatusCode)
+	}
+	return nil
+}
+
+// FileNotifier дописывает уведомления в файл, по одному JSON-объекту на строку
+type FileNotifier struct {
+	Path string
+	mu   sync.Mutex
+}
+
+func NewFileNotifier(path string) *FileNotifier {
+	return &FileNotifier{Path: path}
+}
+
+func (n *FileNotifier) Notify(alert domain.LowStockItem) error {
+	line, err := json.Marshal(alert)
+	if err != nil {
+		return err
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	file, err := os.OpenFile(n.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o666)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = file.Write(append(line, '\n'))
+	return err
+}
+
+// MultiNotifier рассылает уведомление всем получателям
+type MultiNotifier []Notifier
+
+func (m MultiNotifier) Notify(alert domain.LowStockItem) error {
+	var errs []error
+	for _, n := range m {
+		if err := n.Notify(alert); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to deliver alert: %v", errs)
+	}
+	return nil
+}
@@ -0,0 +1,228 @@
+package search
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// Типы индексируемых документов
+const (
+	KindMenu      = "menu"
+	KindOrder     = "order"
+	KindInventory = "inventory"
+)
+
+// Index — инвертированный индекс в памяти: нормализованное слово -> документы, в которых оно встречается.
+// Поиск идет по префиксам слов без учета регистра и диакритики.
+type Index struct {
+	mu       sync.RWMutex
+	postings map[string]map[docKey]struct{}
+	docs     map[docKey][]string
+}
+
+type docKey struct {
+	kind string
+	id   string
+}
+
+// Найденный документ и его релевантность
+type Hit struct {
+	Kind  string
+	ID    string
+	Score int
+}
+
+func NewIndex() *Index {
+	return &Index{
+		postings: make(map[string]map[docKey]struct{}),
+		docs:     make(map[docKey][]string),
+	}
+}
+
+// Put добавляет документ в индекс или заменяет его текст
+func (x *Index) Put(kind, id string, texts ...string) {
+	key := docKey{kind: kind, id: id}
+	tokens := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, text := range texts {
+		for _, token := range Tokenize(text) {
+			if !seen[token] {
+				seen[token] = true
+				tokens = append(tokens, token)
+			}
+		}
+	}
+
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	x.remove(key)
+	x.docs[key] = tokens
+	for _, token := range tokens {
+		if x.postings[token] == nil {
+			x.postings[token] = make(map[docKey]struct{})
+		}
+		x.postings[token][key] = struct{}{}
+	}
+}
+
+// Remove удаляет документ из индекса
+func (x *Index) Remove(kind, id string) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.remove(docKey{kind: kind, id: id})
+}
+
+// Reset удаляет из индекса все документы указанного типа
+func (x *Index) Reset(kind string) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	for key := range x.docs {
+		if key.kind == kind {
+			x.remove(key)
+		}
+	}
+}
+
+func (x *Index) remove(key docKey) {
+	for _, token := range x.docs[key] {
+		delete(x.postings[token], key)
+		if len(x.postings[token]) == 0 {
+			delete(x.postings, token)
+		}
+	}
+	delete(x.docs, key)
+}
+
+// Search находит документы, содержащие все слова запроса (как целые слова или их начало).
+// Полное совпадение слова ценится выше префиксного; результаты упорядочены по релевантности и ID.
+func (x *Index) Search(query string) []Hit {
+	terms := Tokenize(query)
+	if len(terms) == 0 {
+		return nil
+	}
+
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+
+	var scores map[docKey]int
+	for _, term := range terms {
+		termScores := make(map[docKey]int)
+		for token, keys := range x.postings {
+			if !strings.HasPrefix(token, term) {
+				continue
+			}
+			score := 1
+			if token == term {
+				score = 2
+			}
+			for key := range keys {
+				termScores[key] = max(termScores[key], score)
+			}
+		}
+
+		// Документ должен содержать все слова запроса
+		if scores == nil {
+			scores = termScores
+			continue
+		}
+		for key, score := range scores {
+			if termScore, ok := termScores[key]; ok {
+				scores[key] = score + termScore
+			} else {
+				delete(scores, key)
+			}
+		}
+	}
+
+	hits := make([]Hit, 0, len(scores))
+	for key, score := range scores {
+		hits = append(hits, Hit{Kind: key.kind, ID: key.id, Score: score})
+	}
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		if hits[i].Kind != hits[j].Kind {
+			return hits[i].Kind < hits[j].Kind
+		}
+		return hits[i].ID < hits[j].ID
+	})
+	return hits
+}
+
+// Tokenize разбивает текст на нормализованные слова: буквы и цифры в нижнем регистре без диакритики
+func Tokenize(text string) []string {
+	fields := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && !unicode.Is(unicode.Mn, r)
+	})
+
+	tokens := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if token := normalize(field); token != "" {
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens
+}
+
+// normalize приводит слово к нижнему регистру и заменяет буквы с диакритикой базовыми
+func normalize(word string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(word) {
+		if unicode.Is(unicode.Mn, r) {
+			// Комбинируемые диакритические знаки отбрасываются
+			continue
+		}
+		if folded, ok := foldTable[r]; ok {
+			b.WriteRune(folded)
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// Буквы с диакритикой и их базовые буквы: латиница, русский и казахский алфавиты
+var foldTable = buildFoldTable(map[rune]string{
+	'a': "àáâãäåāăą",
+	'c': "çćĉċč",
+	'd': "ďđ",
+	'e': "èéêëēĕėęě",
+	'g': "ĝğġģ",
+	'h': "ĥħ",
+	'i': "ìíîïĩīĭįı",
+	'j': "ĵ",
+	'k': "ķ",
+	'l': "ĺļľŀł",
+	'n': "ñńņňŉ",
+	'o': "òóôõöøōŏő",
+	'r': "ŕŗř",
+	's': "śŝşšș",
+	't': "ţťŧț",
+	'u': "ùúûüũūŭůűų",
+	'w': "ŵ",
+	'y': "ýÿŷ",
+	'z': "źżž",
+	'е': "ё",
+	'и': "йі",
+	'а': "ә",
+	'г': "ғ",
+	'к': "қ",
+	'н': "ң",
+	'о': "ө",
+	'у': "ұүў",
+	'х': "һ",
+})
+
+func buildFoldTable(groups map[rune]string) map[rune]rune {
+	table := make(map[rune]rune)
+	for base, letters := range groups {
+		for _, letter := range letters {
+			table[letter] = base
+		}
+	}
+	return table
+}
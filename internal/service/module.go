@@ -1,41 +1,170 @@
 package service
 
 import (
+	"time"
+
 	"hot-coffee/internal/domain"
+	"hot-coffee/internal/export"
 )
 
 type ServiceModule interface {
 	OrderService
+	PaymentService
 	MenuService
 	InventoryService
+	MovementService
+	SupplierService
+	CustomerService
+	LoyaltyService
+	PromotionService
+	PurchaseOrderService
+	WasteService
+	StockCountService
+	ValuationService
+	ForecastService
+	TrafficService
+	IngredientUsageService
+	DailyReportService
+	SearchService
 	AggregationsService
 }
 
 type OrderService interface {
 	AddOrder(data []byte) (int, error)
-	GetAllOrders() ([]byte, int, error)
+	GetAllOrders(filter domain.OrderFilter, options domain.ListOptions) ([]byte, domain.PageInfo, int, error)
 	GetOrderByID(id string) ([]byte, int, error)
 	UpdateOrderByID(id string, data []byte) (int, error)
 	DeleteOrderByID(id string) (int, error)
-	CloseOrderByID(id string) (int, error)
+	CloseOrderByID(id, user string) (int, error)
+	CancelOrderByID(id string) (int, error)
+	RefundOrderByID(id string, data []byte, user string) ([]byte, int, error)
+}
+
+type PaymentService interface {
+	AddPayment(orderID string, data []byte, user string) ([]byte, int, error)
+	GetOrderPayments(orderID string) ([]byte, int, error)
+	VoidPayment(orderID, paymentID string) (int, error)
 }
 
 type MenuService interface {
 	AddMenu([]byte) (int, error)
-	GetAllMenuItems() ([]byte, int, error)
+	GetAllMenuItems(filter domain.MenuFilter, options domain.ListOptions) ([]byte, domain.PageInfo, int, error)
 	GetMenuItemByID(id string) ([]byte, int, error)
 	UpdateMenuItemByID(id string, data []byte) (int, error)
 	DeleteMenuItemByID(id string) (int, error)
+	ImportMenuItems(data []byte, format export.Format, options domain.ImportOptions) ([]byte, int, error)
 }
 type InventoryService interface {
-	AddInventoryItem(data []byte) (int, error)
-	GetAllInventoryItems() ([]byte, int, error)
+	AddInventoryItem(data []byte, user string) (int, error)
+	GetAllInventoryItems(filter domain.InventoryFilter, options domain.ListOptions) ([]byte, domain.PageInfo, int, error)
 	GetInventoryItemByID(id string) ([]byte, int, error)
-	UpdateInventoryItemByID(id string, data []byte) (int, error)
-	DeleteInventoryItemByID(id string) (int, error)
+	UpdateInventoryItemByID(id string, data []byte, user string) (int, error)
+	DeleteInventoryItemByID(id, user string) (int, error)
+	GetLowStockItems() ([]byte, int, error)
+	AdjustInventoryItem(id string, data []byte, user string) ([]byte, int, error)
+	ReceiveInventory(data []byte, user string) ([]byte, int, error)
+	ImportInventoryItems(data []byte, format export.Format, options domain.ImportOptions, user string) ([]byte, int, error)
+}
+
+type MovementService interface {
+	GetInventoryMovements(id string, from, to time.Time) ([]byte, int, error)
+	GetStockDrift() ([]byte, int, error)
+	RebuildInventoryFromLedger() ([]byte, int, error)
+	GetInventoryLots(id string) ([]byte, int, error)
+	GetExpiringLots(within time.Duration) ([]byte, int, error)
+}
+
+type CustomerService interface {
+	AddCustomer(data []byte) ([]byte, int, error)
+	GetAllCustomers(query string) ([]byte, int, error)
+	GetCustomerByID(id string) ([]byte, int, error)
+	UpdateCustomerByID(id string, data []byte) (int, error)
+	DeleteCustomerByID(id string) (int, error)
+	GetCustomerOrders(id string, filter domain.OrderFilter, options domain.ListOptions) ([]byte, domain.PageInfo, int, error)
+}
+
+type LoyaltyService interface {
+	AddLoyaltyRule(data []byte) (int, error)
+	GetAllLoyaltyRules() ([]byte, int, error)
+	UpdateLoyaltyRuleByID(id string, data []byte) (int, error)
+	DeleteLoyaltyRuleByID(id string) (int, error)
+	AddReward(data []byte) (int, error)
+	GetAllRewards() ([]byte, int, error)
+	UpdateRewardByID(id string, data []byte) (int, error)
+	DeleteRewardByID(id string) (int, error)
+	GetLoyaltyAccount(customerID string) ([]byte, int, error)
+	AdjustLoyaltyPoints(customerID string, data []byte, user string) ([]byte, int, error)
+}
+
+type PromotionService interface {
+	AddPromotion(data []byte) (int, error)
+	GetAllPromotions() ([]byte, int, error)
+	GetPromotionByID(id string) ([]byte, int, error)
+	UpdatePromotionByID(id string, data []byte) (int, error)
+	DeletePromotionByID(id string) (int, error)
+}
+
+type SupplierService interface {
+	AddSupplier(data []byte) (int, error)
+	GetAllSuppliers() ([]byte, int, error)
+	GetSupplierByID(id string) ([]byte, int, error)
+	UpdateSupplierByID(id string, data []byte) (int, error)
+	DeleteSupplierByID(id string) (int, error)
+}
+
+type PurchaseOrderService interface {
+	CreatePurchaseOrder(data []byte) ([]byte, int, error)
+	GeneratePurchaseOrders() ([]byte, int, error)
+	GetAllPurchaseOrders() ([]byte, int, error)
+	GetPurchaseOrderByID(id string) ([]byte, int, error)
+	SendPurchaseOrder(id string) ([]byte, int, error)
+	ReceivePurchaseOrder(id string, data []byte, user string) ([]byte, int, error)
+}
+
+type WasteService interface {
+	WasteIngredient(data []byte, user string) ([]byte, int, error)
+	WasteMenuItem(id string, data []byte, user string) ([]byte, int, error)
+	GetWasteReport(from, to time.Time) (*domain.WasteReport, error)
+}
+
+type StockCountService interface {
+	OpenStockCount(data []byte, user string) ([]byte, int, error)
+	GetAllStockCounts() ([]byte, int, error)
+	GetStockCountByID(id string) ([]byte, int, error)
+	SubmitStockCount(id string, data []byte, user string) ([]byte, int, error)
+	GetStockCountVariance(id string) ([]byte, int, error)
+	CommitStockCount(id, user string) ([]byte, int, error)
+	CancelStockCount(id, user string) ([]byte, int, error)
+}
+
+type ValuationService interface {
+	GetInventoryValuation(from, to time.Time) (*domain.InventoryValuation, error)
+	GetCOGSReport(from, to time.Time) (*domain.COGSReport, error)
+}
+
+type ForecastService interface {
+	GetForecast(lookbackDays, horizonDays int, loc *time.Location) (*domain.Forecast, error)
+}
+
+type TrafficService interface {
+	GetTrafficReport(from, to time.Time, loc *time.Location) (*domain.TrafficReport, error)
+}
+
+type IngredientUsageService interface {
+	GetIngredientUsage(from, to time.Time, threshold float64) (*domain.IngredientUsageReport, error)
+}
+
+type DailyReportService interface {
+	CloseDay(date time.Time, user string) ([]byte, int, error)
+	GetDailyReport(date time.Time) ([]byte, int, error)
+}
+
+type SearchService interface {
+	Search(query string, from, to time.Time, limit int) (*domain.SearchResults, error)
 }
 
 type AggregationsService interface {
-	GetTotalSales() (float64, error)
-	GetPopularItems() ([]domain.ProductSales, error)
+	GetTotalSales(filter domain.ReportFilter) (*domain.SalesReport, error)
+	GetPopularItems(filter domain.ReportFilter, options domain.PopularItemsOptions) ([]domain.ProductSales, error)
+	GetBasketReport(filter domain.ReportFilter, options domain.BasketOptions) (*domain.BasketReport, error)
 }
@@ -0,0 +1,208 @@
+package usecase
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"hot-coffee/internal/domain"
+)
+
+// AdjustInventoryItem изменяет остаток ингредиента на величину delta.
+// Изменение выполняется под блокировкой хранилища, поэтому параллельные продажи не теряются.
+func (a *Application) AdjustInventoryItem(id string, data []byte, user string) ([]byte, int, error) {
+	var adjustment domain.StockAdjustment
+	if err := json.Unmarshal(data, &adjustment); err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("invalid adjustment data")
+	}
+
+	if adjustment.Type == "" {
+		adjustment.Type = domain.MovementAdjustment
+	}
+	if err := validateStockAdjustment(&adjustment); err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+
+	a.Repository.Lock()
+	defer a.unlockAndNotify()
+
+	inventoryItems, err := a.getInventoryItems()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	item := findInventoryItem(id, inventoryItems)
+	if item == nil {
+		return nil, http.StatusNotFound, fmt.Errorf("inventory item with Ingredient ID %s not found", id)
+	}
+
+	delta, err := toInventoryUnit(adjustment.Delta, adjustment.Unit, item)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	if item.Quantity+delta < 0 {
+		return nil, http.StatusConflict, fmt.Errorf("insufficient quantity for ingredient %s: have %.2f %s, adjustment %.2f", id, item.Quantity, item.Unit, delta)
+	}
+	item.Quantity += delta
+
+	if err := a.saveInventoryItems(inventoryItems); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	movement := newMovement(id, adjustment.Type, delta, adjustment.Reason, "", user)
+	movement.ExpiresAt = adjustment.ExpiresAt
+	if delta > 0 {
+		movement.UnitCost = toInventoryUnitCost(adjustment.UnitCost, adjustment.Delta, delta)
+	}
+	if err := a.recordMovements(movement); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	a.evaluateStockLevels(inventoryItems)
+
+	result, err := a.Repository.MarshalJsonInventory(item)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	return result, http.StatusOK, nil
+}
+
+// ReceiveInventory приходует поставку нескольких ингредиентов одной операцией:
+// либо применяются все позиции, либо ни одна.
+func (a *Application) ReceiveInventory(data []byte, user string) ([]byte, int, error) {
+	var receipt domain.StockReceipt
+	if err := json.Unmarshal(data, &receipt); err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("invalid receipt data")
+	}
+
+	if err := validateStockReceipt(&receipt); err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+
+	a.Repository.Lock()
+	defer a.unlockAndNotify()
+
+	inventoryItems, err := a.getInventoryItems()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	// Сначала проверяем все позиции, затем применяем
+	deltas := make([]float64, len(receipt.Items))
+	for i, line := range receipt.Items {
+		item := findInventoryItem(line.IngredientID, inventoryItems)
+		if item == nil {
+			return nil, http.StatusNotFound, fmt.Errorf("inventory item with Ingredient ID %s not found", line.IngredientID)
+		}
+		delta, err := toInventoryUnit(line.Quantity, line.Unit, item)
+		if err != nil {
+			return nil, http.StatusBadRequest, err
+		}
+		deltas[i] = delta
+	}
+
+	movements := make([]*domain.StockMovement, 0, len(receipt.Items))
+	received := make([]*domain.InventoryItem, 0, len(receipt.Items))
+	for i, line := range receipt.Items {
+		item := findInventoryItem(line.IngredientID, inventoryItems)
+		item.Quantity += deltas[i]
+		movement := newMovement(item.IngredientID, domain.MovementRestock, deltas[i], receipt.Reason, "", user)
+		movement.ExpiresAt = line.ExpiresAt
+		movement.UnitCost = toInventoryUnitCost(line.UnitCost, line.Quantity, deltas[i])
+		movements = append(movements, movement)
+		received = append(received, item)
+	}
+
+	if err := a.saveInventoryItems(inventoryItems); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	if err := a.recordMovements(movements...); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	a.evaluateStockLevels(inventoryItems)
+
+	result, err := a.Repository.MarshalInventoryItems(received)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	return result, http.StatusOK, nil
+}
+
+// getInventoryItems читает и десериализует весь инвентарь
+func (a *Application) getInventoryItems() ([]*domain.InventoryItem, error) {
+	data, err := a.Repository.GetInventoryItems()
+	if err != nil {
+		return nil, err
+	}
+	return a.Repository.UnmarshalInventoryItems(data)
+}
+
+// saveInventoryItems сериализует и сохраняет весь инвентарь
+func (a *Application) saveInventoryItems(inventoryItems []*domain.InventoryItem) error {
+	data, err := a.Repository.MarshalInventoryItems(inventoryItems)
+	if err != nil {
+		return err
+	}
+	return a.Repository.SaveInventoryItems(data)
+}
+
+// toInventoryUnit переводит количество в единицы измерения ингредиента
+func toInventoryUnit(quantity float64, unit string, item *domain.InventoryItem) (float64, error) {
+	if unit == "" {
+		return quantity, nil
+	}
+	converted, err := domain.ConvertUnit(quantity, unit, item.Unit)
+	if err != nil {
+		return 0, fmt.Errorf("ingredient %s: %w", item.IngredientID, err)
+	}
+	return converted, nil
+}
+
+// toInventoryUnitCost пересчитывает стоимость единицы из единиц поступления в единицы инвентаря
+func toInventoryUnitCost(unitCost, quantity, converted float64) float64 {
+	if unitCost <= 0 || converted <= 0 {
+		return 0
+	}
+	return unitCost * quantity / converted
+}
+
+func validateStockAdjustment(adjustment *domain.StockAdjustment) error {
+	if adjustment.Delta == 0 {
+		return errors.New("delta must not be zero")
+	}
+	if adjustment.Reason == "" {
+		return errors.New("reason is required")
+	}
+	if !adjustment.Type.IsValid() || adjustment.Type == domain.MovementSale || adjustment.Type == domain.MovementReturn {
+		return fmt.Errorf("invalid adjustment type: %s", adjustment.Type)
+	}
+	if adjustment.UnitCost < 0 {
+		return errors.New("unit cost must not be negative")
+	}
+	return nil
+}
+
+func validateStockReceipt(receipt *domain.StockReceipt) error {
+	if len(receipt.Items) == 0 {
+		return errors.New("receipt must contain at least one item")
+	}
+
+	seen := make(map[string]bool, len(receipt.Items))
+	for _, line := range receipt.Items {
+		if line.IngredientID == "" {
+			return errors.New("ingredient ID is required for each item")
+		}
+		if line.Quantity <= 0 {
+			return fmt.Errorf("quantity for ingredient %s must be greater than zero", line.IngredientID)
+		}
+		if line.UnitCost < 0 {
+			return fmt.Errorf("unit cost for ingredient %s must not be negative", line.IngredientID)
+		}
+		if seen[line.IngredientID] {
+			return fmt.Errorf("ingredient %s is listed more than once", line.IngredientID)
+		}
+		seen[line.IngredientID] = true
+	}
+	return nil
+}
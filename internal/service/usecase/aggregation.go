@@ -1,25 +1,168 @@
 package usecase
 
 import (
+	"cmp"
+	"errors"
 	"fmt"
+	"sort"
+	"time"
 
 	"hot-coffee/internal/domain"
 )
 
-// Пробует вызвать и при выявлении ошибки выводит то что есть какая то проблема
-func (a *Application) GetTotalSales() (float64, error) {
-	totalSales, err := a.Repository.GetTotalSales()
+// Пробует вызвать и при выявлении ошибки выводит то что есть какая то проблема.
+// При заданной разбивке добавляет суммы по периодам, при заданном сравнении — сумму за период сравнения.
+func (a *Application) GetTotalSales(filter domain.ReportFilter) (*domain.SalesReport, error) {
+	totalSales, err := a.Repository.GetTotalSales(filter.From, filter.To)
 	if err != nil {
-		return 0, fmt.Errorf("error fetching total sales: %w", err)
+		return nil, fmt.Errorf("error fetching total sales: %w", err)
 	}
-	return totalSales, nil
+
+	report := &domain.SalesReport{
+		From:       optionalTime(filter.From),
+		To:         optionalTime(filter.To),
+		TotalSales: totalSales,
+		GroupBy:    filter.GroupBy,
+	}
+
+	// Скидки и оплаты по завершенным заказам периода
+	orders, err := a.getOrders()
+	if err != nil {
+		return nil, fmt.Errorf("error fetching orders: %w", err)
+	}
+	menuItems, err := a.getMenuItems()
+	if err != nil {
+		return nil, fmt.Errorf("error fetching menu items: %w", err)
+	}
+	completed := make([]*domain.Order, 0, len(orders))
+	for _, order := range orders {
+		if order.Status == domain.StatusCompleted && inPeriod(order.CreatedAt, filter.From, filter.To) {
+			completed = append(completed, order)
+			report.Discounts += order.Discount()
+		}
+	}
+	report.Discounts = roundMoney(report.Discounts)
+	report.NetSales = roundMoney(totalSales - report.Discounts)
+	report.Promotions = promotionUsage(completed)
+	report.PaymentsByMethod, report.Tips = paymentBreakdown(completed, menuItems)
+
+	if filter.GroupBy != "" {
+		report.Periods, err = a.Repository.GetSalesByPeriod(filter.From, filter.To, filter.GroupBy, reportLocation(filter))
+		if err != nil {
+			return nil, fmt.Errorf("error fetching sales by period: %w", err)
+		}
+	}
+
+	if filter.Compare != "" {
+		if filter.From.IsZero() || filter.To.IsZero() {
+			return nil, errors.New("comparison requires both start_date and end_date")
+		}
+		from, to := filter.Previous()
+		previous, err := a.Repository.GetTotalSales(from, to)
+		if err != nil {
+			return nil, fmt.Errorf("error fetching total sales for comparison: %w", err)
+		}
+
+		report.Comparison = &domain.SalesComparison{
+			Compare:    filter.Compare,
+			From:       from,
+			To:         to,
+			TotalSales: previous,
+			Change:     totalSales - previous,
+		}
+		if previous != 0 {
+			percent := (totalSales - previous) / previous * 100
+			report.Comparison.ChangePercent = &percent
+		}
+	}
+	return report, nil
 }
 
-// Старается взять самые знаменитые позиции
-func (a *Application) GetPopularItems() ([]domain.ProductSales, error) {
-	popularItems, err := a.Repository.GetPopularItems()
+// Старается взять самые знаменитые позиции.
+// Вид least ранжирует позиции по возрастанию и включает непроданные позиции меню, вид unsold — только непроданные.
+func (a *Application) GetPopularItems(filter domain.ReportFilter, options domain.PopularItemsOptions) ([]domain.ProductSales, error) {
+	popularItems, err := a.Repository.GetPopularItems(filter.From, filter.To)
 	if err != nil {
 		return nil, fmt.Errorf("error fetching popular items: %w", err)
 	}
+
+	if options.View == domain.ViewLeast || options.View == domain.ViewUnsold {
+		menuItems, err := a.getMenuItems()
+		if err != nil {
+			return nil, fmt.Errorf("error fetching menu items: %w", err)
+		}
+
+		sold := make(map[string]bool, len(popularItems))
+		for _, item := range popularItems {
+			sold[item.ProductID] = true
+		}
+		if options.View == domain.ViewUnsold {
+			popularItems = popularItems[:0]
+		}
+		for _, menuItem := range menuItems {
+			if !sold[menuItem.ID] {
+				popularItems = append(popularItems, domain.ProductSales{ProductID: menuItem.ID, Name: menuItem.Name})
+			}
+		}
+	}
+
+	sortPopularItems(popularItems, options)
+	if options.Limit > 0 && len(popularItems) > options.Limit {
+		popularItems = popularItems[:options.Limit]
+	}
 	return popularItems, nil
 }
+
+// GetBasketReport анализирует корзины завершенных заказов: оставляет пары с долей заказов не ниже MinSupport
+// и ограничивает их количество
+func (a *Application) GetBasketReport(filter domain.ReportFilter, options domain.BasketOptions) (*domain.BasketReport, error) {
+	report, err := a.Repository.GetBasketReport(filter.From, filter.To)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching basket report: %w", err)
+	}
+	report.From = optionalTime(filter.From)
+	report.To = optionalTime(filter.To)
+	report.MinSupport = options.MinSupport
+
+	pairs := report.Pairs[:0]
+	for _, pair := range report.Pairs {
+		if pair.Support >= options.MinSupport {
+			pairs = append(pairs, pair)
+		}
+	}
+	if options.Limit > 0 && len(pairs) > options.Limit {
+		pairs = pairs[:options.Limit]
+	}
+	report.Pairs = pairs
+	return report, nil
+}
+
+// sortPopularItems упорядочивает позиции по выбранному показателю, затем по второму показателю и ID
+func sortPopularItems(items []domain.ProductSales, options domain.PopularItemsOptions) {
+	ascending := options.View == domain.ViewLeast || options.View == domain.ViewUnsold
+	sort.SliceStable(items, func(i, j int) bool {
+		primary := cmp.Compare(items[i].Quantity, items[j].Quantity)
+		secondary := cmp.Compare(items[i].Revenue, items[j].Revenue)
+		if options.Sort == domain.SortByRevenue {
+			primary, secondary = secondary, primary
+		}
+		if primary == 0 {
+			primary = secondary
+		}
+		if primary == 0 {
+			return items[i].ProductID < items[j].ProductID
+		}
+		if ascending {
+			return primary < 0
+		}
+		return primary > 0
+	})
+}
+
+// reportLocation возвращает часовой пояс отчета; по умолчанию местный
+func reportLocation(filter domain.ReportFilter) *time.Location {
+	if filter.Location != nil {
+		return filter.Location
+	}
+	return time.Local
+}
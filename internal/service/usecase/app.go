@@ -1,11 +1,56 @@
 package usecase
 
-import "hot-coffee/internal/dal"
+import (
+	"log"
+	"sync"
+
+	"hot-coffee/internal/dal"
+	"hot-coffee/internal/domain"
+	"hot-coffee/internal/notifier"
+	"hot-coffee/internal/search"
+)
 
 type Application struct {
 	Repository dal.DataRepository
+	Notifier   notifier.Notifier
+	Logger     *log.Logger
+
+	// Метод расчета себестоимости списаний; по умолчанию FIFO
+	CostingMethod domain.CostingMethod
+
+	// Ставка налога в процентах, включенного в цены меню
+	TaxRate float64
+
+	// Ингредиенты, по которым уже отправлено уведомление о низком остатке,
+	// уведомления, ожидающие снятия блокировки, и очередь отправки
+	alerted       map[string]bool
+	pendingAlerts []domain.LowStockItem
+	alerts        chan domain.LowStockItem
+	alertsMu      sync.Mutex
+
+	// Поисковый индекс по меню, заказам и инвентарю
+	searchIndex *search.Index
 }
 
 func NewApplication(repoObject dal.DataRepository) *Application {
-	return &Application{Repository: repoObject}
+	return &Application{
+		Repository:  repoObject,
+		alerted:     make(map[string]bool),
+		alerts:      make(chan domain.LowStockItem, alertQueueSize),
+		searchIndex: search.NewIndex(),
+	}
+}
+
+func (a *Application) logger() *log.Logger {
+	if a.Logger != nil {
+		return a.Logger
+	}
+	return log.Default()
+}
+
+func (a *Application) costingMethod() domain.CostingMethod {
+	if a.CostingMethod.IsValid() {
+		return a.CostingMethod
+	}
+	return domain.CostingFIFO
 }
@@ -0,0 +1,99 @@
+package usecase
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"hot-coffee/internal/domain"
+)
+
+// orderItemRecipe возвращает ингредиенты одной штуки позиции заказа.
+// Рецепт комбо складывается из собственных ингредиентов комбо и рецептов компонентов с учетом выбора в слотах.
+func orderItemRecipe(item domain.OrderItem, menuItem *domain.MenuItem, menuItems []*domain.MenuItem) ([]domain.MenuItemIngredient, error) {
+	if !menuItem.IsBundle() {
+		if len(item.Choices) > 0 {
+			return nil, fmt.Errorf("menu item %s is not a bundle and has no choices", menuItem.ID)
+		}
+		return menuItem.Ingredients, nil
+	}
+
+	lines, err := menuItem.BundleLines(item.Choices, menuLookup(menuItems))
+	if err != nil {
+		return nil, err
+	}
+
+	recipe := slices.Clone(menuItem.Ingredients)
+	for _, line := range lines {
+		component := findMenuItem(line.ProductID, menuItems)
+		if component == nil {
+			return nil, fmt.Errorf("component %s of bundle %s not found", line.ProductID, menuItem.ID)
+		}
+		for _, ingredient := range component.Ingredients {
+			ingredient.Quantity *= float64(line.Quantity)
+			recipe = addRecipeIngredient(recipe, ingredient)
+		}
+	}
+	return recipe, nil
+}
+
+// addRecipeIngredient добавляет ингредиент в рецепт, складывая количества одного ингредиента в одной единице
+func addRecipeIngredient(recipe []domain.MenuItemIngredient, ingredient domain.MenuItemIngredient) []domain.MenuItemIngredient {
+	for i := range recipe {
+		if recipe[i].IngredientID == ingredient.IngredientID && recipe[i].Unit == ingredient.Unit {
+			recipe[i].Quantity += ingredient.Quantity
+			return recipe
+		}
+	}
+	return append(recipe, ingredient)
+}
+
+// checkBundleComponents проверяет, что компоненты комбо есть в меню и сами не являются комбо,
+// а в категории каждого слота выбора есть хотя бы одна позиция
+func checkBundleComponents(bundle *domain.MenuItem, menuItems []*domain.MenuItem) error {
+	if !bundle.IsBundle() {
+		return nil
+	}
+	// Позиция, входящая в комбо, не может стать комбо
+	if parent := findBundleWith(bundle.ID, menuItems); parent != nil {
+		return fmt.Errorf("menu item %s is a component of bundle %s and can't be a bundle", bundle.ID, parent.ID)
+	}
+
+	for _, component := range bundle.Components {
+		if component.IsChoice() {
+			if !slices.ContainsFunc(menuItems, func(item *domain.MenuItem) bool {
+				return !item.IsBundle() && item.ID != bundle.ID && strings.EqualFold(item.Category, component.Category)
+			}) {
+				return fmt.Errorf("bundle %s has no menu items in category %s", bundle.ID, component.Category)
+			}
+			continue
+		}
+
+		item := findMenuItem(component.ProductID, menuItems)
+		if item == nil || item.ID == bundle.ID {
+			return fmt.Errorf("component %s of bundle %s not found", component.ProductID, bundle.ID)
+		}
+		if item.IsBundle() {
+			return fmt.Errorf("bundle %s can't be a component of bundle %s", item.ID, bundle.ID)
+		}
+	}
+	return nil
+}
+
+// findBundleWith находит комбо, в состав которого входит позиция id
+func findBundleWith(id string, menuItems []*domain.MenuItem) *domain.MenuItem {
+	for _, item := range menuItems {
+		for _, component := range item.Components {
+			if component.ProductID == id {
+				return item
+			}
+		}
+	}
+	return nil
+}
+
+func menuLookup(menuItems []*domain.MenuItem) func(id string) *domain.MenuItem {
+	return func(id string) *domain.MenuItem {
+		return findMenuItem(id, menuItems)
+	}
+}
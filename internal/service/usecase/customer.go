@@ -0,0 +1,354 @@
+package usecase
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"hot-coffee/internal/domain"
+)
+
+// Количество любимых позиций в статистике клиента
+const customerFavoriteItems = 3
+
+func (a *Application) AddCustomer(data []byte) ([]byte, int, error) {
+	customer, err := a.Repository.UnmarshalJsonCustomer(data)
+	if err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("invalid customer data")
+	}
+
+	customer.ID = generateID("CUS")
+	customer.CreatedAt = time.Now()
+	normalizeCustomer(customer)
+	if err := validateCustomer(customer); err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+
+	a.Repository.Lock()
+	defer a.Repository.Unlock()
+
+	customers, err := a.getCustomers()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if err := checkCustomerContacts(customer, customers); err != nil {
+		return nil, http.StatusConflict, err
+	}
+
+	customers = append(customers, customer)
+	if err := a.saveCustomers(customers); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	data, err = a.Repository.MarshalJsonCustomer(customer)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	return data, http.StatusCreated, nil
+}
+
+// GetAllCustomers возвращает клиентов; непустой query ищется в имени, телефоне и почте
+func (a *Application) GetAllCustomers(query string) ([]byte, int, error) {
+	customers, err := a.getCustomers()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	result := make([]*domain.Customer, 0, len(customers))
+	for _, customer := range customers {
+		if query == "" || customerMatches(customer, query) {
+			result = append(result, customer)
+		}
+	}
+
+	data, err := a.Repository.MarshalJsonCustomers(result)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	return data, http.StatusOK, nil
+}
+
+// GetCustomerByID возвращает клиента вместе со статистикой его завершенных заказов
+func (a *Application) GetCustomerByID(id string) ([]byte, int, error) {
+	customers, err := a.getCustomers()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	customer := findCustomer(id, customers)
+	if customer == nil {
+		return nil, http.StatusNotFound, fmt.Errorf("customer with ID %s not found", id)
+	}
+
+	stats, err := a.customerStats(id)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	data, err := json.Marshal(domain.CustomerProfile{Customer: *customer, Stats: *stats})
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	return data, http.StatusOK, nil
+}
+
+func (a *Application) UpdateCustomerByID(id string, data []byte) (int, error) {
+	customer, err := a.Repository.UnmarshalJsonCustomer(data)
+	if err != nil {
+		return http.StatusBadRequest, fmt.Errorf("invalid customer data")
+	}
+
+	customer.ID = id
+	normalizeCustomer(customer)
+	if err := validateCustomer(customer); err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	a.Repository.Lock()
+	defer a.Repository.Unlock()
+
+	customers, err := a.getCustomers()
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	existing := findCustomer(id, customers)
+	if existing == nil {
+		return http.StatusNotFound, fmt.Errorf("customer with ID %s not found", id)
+	}
+	if err := checkCustomerContacts(customer, customers); err != nil {
+		return http.StatusConflict, err
+	}
+
+	customer.CreatedAt = existing.CreatedAt
+	*existing = *customer
+	if err := a.saveCustomers(customers); err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	return http.StatusOK, nil
+}
+
+// DeleteCustomerByID удаляет клиента без незавершенных заказов и баллов. Его заказы сохраняют имя клиента и остаются в отчетах.
+func (a *Application) DeleteCustomerByID(id string) (int, error) {
+	a.Repository.Lock()
+	defer a.Repository.Unlock()
+
+	customers, err := a.getCustomers()
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	index := slices.IndexFunc(customers, func(customer *domain.Customer) bool { return customer.ID == id })
+	if index == -1 {
+		return http.StatusNotFound, fmt.Errorf("customer with ID %s not found", id)
+	}
+
+	// Клиента с незавершенными заказами или баллами на счету удалять нельзя: они ссылаются на него
+	orders, err := a.getOrders()
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	for _, order := range orders {
+		if order.CustomerID == id && order.Status == domain.StatusPending {
+			return http.StatusConflict, fmt.Errorf("customer %s has pending order %s", id, order.ID)
+		}
+	}
+	ledger, err := a.getLoyaltyLedger()
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	if balance := loyaltyBalance(id, ledger); balance != 0 {
+		return http.StatusConflict, fmt.Errorf("customer %s has a loyalty balance of %d points", id, balance)
+	}
+	customers = slices.Delete(customers, index, index+1)
+
+	if err := a.saveCustomers(customers); err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	return http.StatusNoContent, nil
+}
+
+// GetCustomerOrders возвращает страницу истории заказов клиента
+func (a *Application) GetCustomerOrders(id string, filter domain.OrderFilter, options domain.ListOptions) ([]byte, domain.PageInfo, int, error) {
+	customers, err := a.getCustomers()
+	if err != nil {
+		return nil, domain.PageInfo{}, http.StatusInternalServerError, err
+	}
+	if findCustomer(id, customers) == nil {
+		return nil, domain.PageInfo{}, http.StatusNotFound, fmt.Errorf("customer with ID %s not found", id)
+	}
+
+	filter.CustomerID = id
+	return a.GetAllOrders(filter, options)
+}
+
+// customerStats подсчитывает визиты, траты за вычетом скидок и любимые позиции клиента по завершенным заказам.
+// Траты считаются по зафиксированным в заказах ценам, а для старых заказов — по ценам меню.
+func (a *Application) customerStats(id string) (*domain.CustomerStats, error) {
+	orders, err := a.getOrders()
+	if err != nil {
+		return nil, err
+	}
+
+	menuItems, err := a.getMenuItems()
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &domain.CustomerStats{}
+	itemSales := make(map[string]*domain.ProductSales)
+	for _, order := range orders {
+		if order.CustomerID != id || order.Status != domain.StatusCompleted {
+			continue
+		}
+
+		stats.Visits++
+		stats.TotalSpent -= order.Discount()
+		if stats.FirstVisit == nil || order.CreatedAt.Before(*stats.FirstVisit) {
+			stats.FirstVisit = &order.CreatedAt
+		}
+		if stats.LastVisit == nil || order.CreatedAt.After(*stats.LastVisit) {
+			stats.LastVisit = &order.CreatedAt
+		}
+
+		for _, item := range order.Items {
+			sales, ok := itemSales[item.ProductID]
+			if !ok {
+				sales = &domain.ProductSales{ProductID: item.ProductID}
+				itemSales[item.ProductID] = sales
+			}
+			sales.Quantity += item.Quantity
+			if menuItem := findMenuItem(item.ProductID, menuItems); menuItem != nil {
+				sales.Name = menuItem.Name
+			}
+			// Стоимость удаленных из меню товаров без зафиксированной цены неизвестна
+			if price, ok := item.UnitPrice(menuLookup(menuItems)); ok {
+				sales.Revenue += float64(item.Quantity) * price
+				stats.TotalSpent += float64(item.Quantity) * price
+			}
+		}
+	}
+
+	if stats.Visits > 0 {
+		stats.AverageOrderValue = roundMoney(stats.TotalSpent / float64(stats.Visits))
+	}
+	stats.TotalSpent = roundMoney(stats.TotalSpent)
+
+	stats.FavoriteItems = make([]domain.ProductSales, 0, len(itemSales))
+	for _, sales := range itemSales {
+		sales.Revenue = roundMoney(sales.Revenue)
+		stats.FavoriteItems = append(stats.FavoriteItems, *sales)
+	}
+	sortPopularItems(stats.FavoriteItems, domain.PopularItemsOptions{Sort: domain.SortByQuantity})
+	if len(stats.FavoriteItems) > customerFavoriteItems {
+		stats.FavoriteItems = stats.FavoriteItems[:customerFavoriteItems]
+	}
+	return stats, nil
+}
+
+// linkOrderCustomer проверяет клиента заказа и подставляет его имя, если оно не указано.
+// Заказы без customer_id оформляются только по имени.
+func (a *Application) linkOrderCustomer(order *domain.Order) (int, error) {
+	if order.CustomerID == "" {
+		return http.StatusOK, nil
+	}
+
+	customers, err := a.getCustomers()
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	customer := findCustomer(order.CustomerID, customers)
+	if customer == nil {
+		return http.StatusBadRequest, fmt.Errorf("customer %s not found", order.CustomerID)
+	}
+	if order.CustomerName == "" {
+		order.CustomerName = customer.Name
+	}
+	return http.StatusOK, nil
+}
+
+func (a *Application) getCustomers() ([]*domain.Customer, error) {
+	data, err := a.Repository.GetCustomers()
+	if err != nil {
+		return nil, err
+	}
+	return a.Repository.UnmarshalJsonCustomers(data)
+}
+
+func (a *Application) saveCustomers(customers []*domain.Customer) error {
+	data, err := a.Repository.MarshalJsonCustomers(customers)
+	if err != nil {
+		return err
+	}
+	return a.Repository.SaveCustomers(data)
+}
+
+func findCustomer(id string, customers []*domain.Customer) *domain.Customer {
+	for _, customer := range customers {
+		if customer.ID == id {
+			return customer
+		}
+	}
+	return nil
+}
+
+func customerMatches(customer *domain.Customer, query string) bool {
+	query = strings.ToLower(query)
+	return strings.Contains(strings.ToLower(customer.Name), query) ||
+		strings.Contains(strings.ToLower(customer.Email), query) ||
+		(customer.Phone != "" && strings.Contains(customer.Phone, query))
+}
+
+// checkCustomerContacts запрещает двух клиентов с одним телефоном или почтой, чтобы постоянных клиентов можно было узнать
+func checkCustomerContacts(customer *domain.Customer, customers []*domain.Customer) error {
+	for _, item := range customers {
+		if item.ID == customer.ID {
+			continue
+		}
+		if customer.Phone != "" && item.Phone == customer.Phone {
+			return fmt.Errorf("phone %s already belongs to customer %s", customer.Phone, item.ID)
+		}
+		if customer.Email != "" && item.Email == customer.Email {
+			return fmt.Errorf("email %s already belongs to customer %s", customer.Email, item.ID)
+		}
+	}
+	return nil
+}
+
+// normalizeCustomer убирает лишние пробелы, приводит почту к нижнему регистру и отбрасывает пустые предпочтения
+func normalizeCustomer(customer *domain.Customer) {
+	customer.Name = strings.TrimSpace(customer.Name)
+	customer.Phone = strings.TrimSpace(customer.Phone)
+	customer.Email = strings.ToLower(strings.TrimSpace(customer.Email))
+
+	preferences := make([]string, 0, len(customer.Preferences))
+	for _, preference := range customer.Preferences {
+		if preference = strings.TrimSpace(preference); preference != "" {
+			preferences = append(preferences, preference)
+		}
+	}
+	customer.Preferences = preferences
+}
+
+func validateCustomer(customer *domain.Customer) error {
+	if customer.Name == "" {
+		return errors.New("customer name is required")
+	}
+	if customer.Email != "" && !strings.Contains(customer.Email, "@") {
+		return fmt.Errorf("invalid email: %q", customer.Email)
+	}
+	if customer.Phone != "" {
+		for _, r := range customer.Phone {
+			if !strings.ContainsRune("+0123456789 -()", r) {
+				return fmt.Errorf("invalid phone: %q", customer.Phone)
+			}
+		}
+	}
+	return nil
+}
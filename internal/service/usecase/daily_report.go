@@ -0,0 +1,282 @@
+package usecase
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"time"
+
+	"hot-coffee/internal/domain"
+)
+
+// Количество позиций в топе продаж отчета о закрытии дня
+const dailyTopItems = 5
+
+// Способ оплаты неоплаченной части заказов, закрытых до учета оплат
+const paymentUnspecified = "unspecified"
+
+// CloseDay закрывает рабочий день и сохраняет его итоги.
+// Закрыть можно только прошедший или текущий день без незавершенных заказов; повторное закрытие запрещено.
+func (a *Application) CloseDay(date time.Time, user string) ([]byte, int, error) {
+	a.Repository.Lock()
+	defer a.Repository.Unlock()
+
+	if date.After(startOfDay(time.Now())) {
+		return nil, http.StatusBadRequest, fmt.Errorf("business day %s has not started yet", businessDay(date))
+	}
+
+	reports, err := a.getDailyReports()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if findDailyReport(businessDay(date), reports) != nil {
+		return nil, http.StatusConflict, fmt.Errorf("business day %s is already closed", businessDay(date))
+	}
+
+	report, err := a.dailyReport(date)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if report.PendingOrders > 0 {
+		return nil, http.StatusConflict, fmt.Errorf("business day %s has %d pending orders", report.Date, report.PendingOrders)
+	}
+
+	closedAt := time.Now()
+	report.Closed = true
+	report.ClosedAt = &closedAt
+	report.ClosedBy = user
+
+	reports = append(reports, report)
+	if err := a.saveDailyReports(reports); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	data, err := json.Marshal(report)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	return data, http.StatusCreated, nil
+}
+
+// GetDailyReport возвращает сохраненный отчет о закрытом дне, а для незакрытого дня — текущие итоги
+func (a *Application) GetDailyReport(date time.Time) ([]byte, int, error) {
+	reports, err := a.getDailyReports()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	report := findDailyReport(businessDay(date), reports)
+	if report == nil {
+		report, err = a.dailyReport(date)
+		if err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+	}
+
+	data, err := json.Marshal(report)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	return data, http.StatusOK, nil
+}
+
+// dailyReport подсчитывает итоги заказов, созданных в рабочий день date, и возвратов, оформленных в этот день.
+// Возвращенный заказ остается в продажах своего дня, а его сумма, оплаты и вернувшиеся на склад ингредиенты вычитаются в день возврата.
+// Выручка считается по зафиксированным в заказах ценам (для старых заказов — по ценам меню), расход ингредиентов — по движениям продаж заказов дня.
+func (a *Application) dailyReport(date time.Time) (*domain.DailyReport, error) {
+	orders, err := a.getOrders()
+	if err != nil {
+		return nil, err
+	}
+
+	menuItems, err := a.getMenuItems()
+	if err != nil {
+		return nil, err
+	}
+
+	inventoryItems, err := a.getInventoryItems()
+	if err != nil {
+		return nil, err
+	}
+
+	ledger, err := a.getMovements()
+	if err != nil {
+		return nil, err
+	}
+
+	report := &domain.DailyReport{
+		Date:    businessDay(date),
+		TaxRate: a.TaxRate,
+	}
+
+	completed := make(map[string]bool)
+	completedOrders := make([]*domain.Order, 0)
+	refundedOrders := make([]*domain.Order, 0)
+	itemSales := make(map[string]*domain.ProductSales)
+	for _, order := range orders {
+		if order.Status == domain.StatusRefunded && order.Refund != nil && businessDay(order.Refund.RefundedAt) == report.Date {
+			refundedOrders = append(refundedOrders, order)
+		}
+		if businessDay(order.CreatedAt) != report.Date {
+			continue
+		}
+		switch order.Status {
+		case domain.StatusPending:
+			report.PendingOrders++
+			continue
+		case domain.StatusCancelled:
+			report.Cancellations++
+			continue
+		}
+
+		report.Orders++
+		report.Discounts += order.Discount()
+		completed[order.ID] = true
+		completedOrders = append(completedOrders, order)
+		for _, item := range order.Items {
+			sales := productSales(item.ProductID, itemSales, menuItems)
+			sales.Quantity += item.Quantity
+			// Выручка удаленных из меню товаров без зафиксированной цены неизвестна
+			price, ok := item.UnitPrice(menuLookup(menuItems))
+			if !ok {
+				continue
+			}
+			sales.Revenue += float64(item.Quantity) * price
+			report.GrossSales += float64(item.Quantity) * price
+
+			// Компоненты комбо получают долю его выручки
+			menuItem := findMenuItem(item.ProductID, menuItems)
+			if menuItem == nil || !menuItem.IsBundle() {
+				continue
+			}
+			lines, err := menuItem.BundleLines(item.Choices, menuLookup(menuItems))
+			if err != nil {
+				continue
+			}
+			for i, revenue := range menuItem.BundleRevenue(price, lines, menuLookup(menuItems)) {
+				component := productSales(lines[i].ProductID, itemSales, menuItems)
+				component.BundleQuantity += lines[i].Quantity * item.Quantity
+				component.BundleRevenue += revenue * float64(item.Quantity)
+			}
+		}
+	}
+
+	report.Refunds = len(refundedOrders)
+	for _, order := range refundedOrders {
+		report.Refunded += orderPayments(order, menuItems).Total
+	}
+
+	// Цены включают налог: выделяем его из суммы после скидок и возвратов
+	taxable := report.GrossSales - report.Discounts - report.Refunded
+	report.Taxes = roundMoney(taxable - taxable/(1+report.TaxRate/100))
+	report.NetSales = roundMoney(taxable - report.Taxes)
+	report.GrossSales = roundMoney(report.GrossSales)
+	report.Discounts = roundMoney(report.Discounts)
+	report.Refunded = roundMoney(report.Refunded)
+	report.PaymentsByMethod, report.Tips = paymentBreakdown(completedOrders, menuItems)
+	report.Tips = refundBreakdown(refundedOrders, menuItems, report.PaymentsByMethod, report.Tips)
+
+	report.TopItems = make([]domain.ProductSales, 0, len(itemSales))
+	for _, sales := range itemSales {
+		sales.BundleRevenue = roundMoney(sales.BundleRevenue)
+		report.TopItems = append(report.TopItems, *sales)
+	}
+	sortPopularItems(report.TopItems, domain.PopularItemsOptions{Sort: domain.SortByQuantity})
+	if len(report.TopItems) > dailyTopItems {
+		report.TopItems = report.TopItems[:dailyTopItems]
+	}
+	report.Promotions = promotionUsage(completedOrders)
+
+	byIngredient := make(map[string]*domain.IngredientCOGS)
+	for _, movement := range ledger {
+		// Ингредиенты, вернувшиеся на склад с возвратом, уменьшают расход дня возврата
+		sold := movement.Type == domain.MovementSale && completed[movement.OrderID]
+		returned := movement.Type == domain.MovementReturn && businessDay(movement.CreatedAt) == report.Date
+		if !sold && !returned {
+			continue
+		}
+		usage, ok := byIngredient[movement.IngredientID]
+		if !ok {
+			usage = &domain.IngredientCOGS{IngredientID: movement.IngredientID}
+			if item := findInventoryItem(movement.IngredientID, inventoryItems); item != nil {
+				usage.Name = item.Name
+				usage.Unit = item.Unit
+			}
+			byIngredient[movement.IngredientID] = usage
+		}
+		usage.Quantity -= movement.Delta
+		usage.Cost -= movement.Cost
+	}
+
+	report.IngredientUsage = make([]domain.IngredientCOGS, 0, len(byIngredient))
+	for _, usage := range byIngredient {
+		report.IngredientUsage = append(report.IngredientUsage, *usage)
+	}
+	sort.Slice(report.IngredientUsage, func(i, j int) bool {
+		return report.IngredientUsage[i].IngredientID < report.IngredientUsage[j].IngredientID
+	})
+
+	return report, nil
+}
+
+// checkDayOpen запрещает изменения заказов рабочего дня, в который попадает t, если день закрыт
+func (a *Application) checkDayOpen(t time.Time) (int, error) {
+	reports, err := a.getDailyReports()
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	if findDailyReport(businessDay(t), reports) != nil {
+		return http.StatusConflict, fmt.Errorf("business day %s is closed", businessDay(t))
+	}
+	return http.StatusOK, nil
+}
+
+// businessDay возвращает рабочий день момента t в местном часовом поясе
+func businessDay(t time.Time) string {
+	return t.In(time.Local).Format(time.DateOnly)
+}
+
+// roundMoney округляет денежную сумму до копеек
+func roundMoney(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
+
+func (a *Application) getDailyReports() ([]*domain.DailyReport, error) {
+	data, err := a.Repository.GetDailyReports()
+	if err != nil {
+		return nil, err
+	}
+	return a.Repository.UnmarshalJsonDailyReports(data)
+}
+
+func (a *Application) saveDailyReports(reports []*domain.DailyReport) error {
+	data, err := a.Repository.MarshalJsonDailyReports(reports)
+	if err != nil {
+		return err
+	}
+	return a.Repository.SaveDailyReports(data)
+}
+
+// productSales возвращает продажи позиции, добавляя их в itemSales при первом обращении
+func productSales(productID string, itemSales map[string]*domain.ProductSales, menuItems []*domain.MenuItem) *domain.ProductSales {
+	sales, ok := itemSales[productID]
+	if !ok {
+		sales = &domain.ProductSales{ProductID: productID}
+		if menuItem := findMenuItem(productID, menuItems); menuItem != nil {
+			sales.Name = menuItem.Name
+		}
+		itemSales[productID] = sales
+	}
+	return sales
+}
+
+func findDailyReport(date string, reports []*domain.DailyReport) *domain.DailyReport {
+	for _, report := range reports {
+		if report.Date == date {
+			return report
+		}
+	}
+	return nil
+}
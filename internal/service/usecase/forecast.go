@@ -0,0 +1,189 @@
+package usecase
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"hot-coffee/internal/domain"
+)
+
+// Предел, дальше которого дата исчерпания остатка не ищется
+const maxForecastDays = 365
+
+// GetForecast прогнозирует расход ингредиентов по завершенным заказам за последние lookbackDays дней.
+// Расход считается отдельно для каждого дня недели; рекомендуемый заказ покрывает срок поставки,
+// horizonDays дней прогноза и точку заказа с учетом уже заказанного. Дни недели считаются в часовом поясе loc.
+func (a *Application) GetForecast(lookbackDays, horizonDays int, loc *time.Location) (*domain.Forecast, error) {
+	orders, err := a.getOrders()
+	if err != nil {
+		return nil, err
+	}
+
+	menuItems, err := a.getMenuItems()
+	if err != nil {
+		return nil, err
+	}
+
+	inventoryItems, err := a.getInventoryItems()
+	if err != nil {
+		return nil, err
+	}
+
+	suppliers, err := a.getSuppliers()
+	if err != nil {
+		return nil, err
+	}
+
+	purchaseOrders, err := a.getPurchaseOrders()
+	if err != nil {
+		return nil, err
+	}
+	onOrder := outstandingQuantities(purchaseOrders, inventoryItems)
+
+	// Окно истории — полные дни до начала сегодняшнего
+	now := time.Now().In(loc)
+	today := startOfDay(now)
+	from := today.AddDate(0, 0, -lookbackDays)
+
+	weekdays := make(map[time.Weekday]int, 7)
+	for day := from; day.Before(today); day = day.AddDate(0, 0, 1) {
+		weekdays[day.Weekday()]++
+	}
+
+	usage := make(map[string]*[7]float64)
+	for _, order := range orders {
+		if order.Status != domain.StatusCompleted || !inPeriod(order.CreatedAt, from, today) {
+			continue
+		}
+		weekday := order.CreatedAt.In(loc).Weekday()
+		for _, orderItem := range order.Items {
+			menuItem := findMenuItem(orderItem.ProductID, menuItems)
+			if menuItem == nil {
+				continue
+			}
+			recipe, err := orderItemRecipe(orderItem, menuItem, menuItems)
+			if err != nil {
+				continue
+			}
+			for _, ingredient := range recipe {
+				inventoryItem := findInventoryItem(ingredient.IngredientID, inventoryItems)
+				if inventoryItem == nil {
+					continue
+				}
+				required, err := requiredQuantity(ingredient, orderItem.Quantity, inventoryItem)
+				if err != nil {
+					continue
+				}
+				if usage[ingredient.IngredientID] == nil {
+					usage[ingredient.IngredientID] = &[7]float64{}
+				}
+				usage[ingredient.IngredientID][weekday] += required
+			}
+		}
+	}
+
+	forecast := &domain.Forecast{
+		GeneratedAt:  now,
+		LookbackDays: lookbackDays,
+		HorizonDays:  horizonDays,
+		Items:        make([]domain.IngredientForecast, 0, len(inventoryItems)),
+	}
+	for _, inventoryItem := range inventoryItems {
+		// Средний расход в каждый день недели
+		var rates [7]float64
+		total := 0.0
+		if consumed := usage[inventoryItem.IngredientID]; consumed != nil {
+			for weekday, quantity := range consumed {
+				total += quantity
+				if weekdays[time.Weekday(weekday)] > 0 {
+					rates[weekday] = quantity / float64(weekdays[time.Weekday(weekday)])
+				}
+			}
+		}
+
+		item := domain.IngredientForecast{
+			IngredientID:   inventoryItem.IngredientID,
+			Name:           inventoryItem.Name,
+			Unit:           inventoryItem.Unit,
+			Quantity:       inventoryItem.Quantity,
+			OnOrder:        onOrder[inventoryItem.IngredientID],
+			WeekdayUsage:   make(map[string]float64, 7),
+			ProjectedUsage: projectUsage(rates, now, float64(horizonDays)),
+		}
+		if lookbackDays > 0 {
+			item.AverageDailyUsage = total / float64(lookbackDays)
+		}
+		for weekday, rate := range rates {
+			item.WeekdayUsage[strings.ToLower(time.Weekday(weekday).String())] = rate
+		}
+
+		if days, ok := daysUntilStockout(rates, now, inventoryItem.Quantity); ok {
+			stockoutAt := now.Add(time.Duration(days * float64(24*time.Hour)))
+			item.DaysUntilStockout = &days
+			item.StockoutAt = &stockoutAt
+		}
+
+		if supplier, _, _ := cheapestSupplier(inventoryItem, suppliers); supplier != nil {
+			item.LeadTimeDays = supplier.LeadTimeDays
+		}
+		needed := projectUsage(rates, now, float64(item.LeadTimeDays+horizonDays)) + inventoryItem.ReorderPoint
+		item.SuggestedQuantity = max(0, needed-inventoryItem.Quantity-item.OnOrder)
+
+		forecast.Items = append(forecast.Items, item)
+	}
+
+	// Сначала ингредиенты, которые закончатся раньше
+	sort.SliceStable(forecast.Items, func(i, j int) bool {
+		left, right := forecast.Items[i].DaysUntilStockout, forecast.Items[j].DaysUntilStockout
+		switch {
+		case left != nil && right != nil:
+			return *left < *right
+		case left != nil || right != nil:
+			return left != nil
+		}
+		return forecast.Items[i].IngredientID < forecast.Items[j].IngredientID
+	})
+	return forecast, nil
+}
+
+// projectUsage рассчитывает ожидаемый расход за days дней начиная с момента now
+func projectUsage(rates [7]float64, now time.Time, days float64) float64 {
+	usage := 0.0
+	for start := now; days > 0; {
+		next := startOfDay(start).AddDate(0, 0, 1)
+		fraction := min(next.Sub(start).Hours()/24, days)
+		usage += rates[start.Weekday()] * fraction
+		days -= fraction
+		start = next
+	}
+	return usage
+}
+
+// daysUntilStockout находит, через сколько дней закончится остаток quantity при расходе по дням недели.
+// Возвращает false, если остаток не закончится в пределах maxForecastDays.
+func daysUntilStockout(rates [7]float64, now time.Time, quantity float64) (float64, bool) {
+	if quantity <= 0 {
+		return 0, true
+	}
+
+	elapsed := 0.0
+	for start := now; elapsed < maxForecastDays; {
+		next := startOfDay(start).AddDate(0, 0, 1)
+		fraction := next.Sub(start).Hours() / 24
+		consumed := rates[start.Weekday()] * fraction
+		if consumed >= quantity {
+			return elapsed + fraction*quantity/consumed, true
+		}
+		quantity -= consumed
+		elapsed += fraction
+		start = next
+	}
+	return 0, false
+}
+
+// startOfDay возвращает полночь дня t в его часовом поясе
+func startOfDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,397 @@
+package usecase
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"hot-coffee/internal/domain"
+	"hot-coffee/internal/export"
+	"hot-coffee/internal/search"
+)
+
+// Колонки CSV импорта; совпадают с колонками выгрузки, поэтому выгруженный файл можно импортировать обратно
+var (
+	menuImportColumns      = []string{"product_id", "name", "description", "category", "price", "ingredients", "components"}
+	inventoryImportColumns = []string{"ingredient_id", "name", "quantity", "unit", "reorder_point", "par_level", "low_stock"}
+)
+
+// ImportMenuItems импортирует позиции меню из JSON-массива или CSV.
+// Изменения применяются только если все строки прошли проверку; при dry run только возвращаются результаты проверки.
+func (a *Application) ImportMenuItems(data []byte, format export.Format, options domain.ImportOptions) ([]byte, int, error) {
+	a.Repository.Lock()
+	defer a.Repository.Unlock()
+
+	items, rowErrors, err := decodeImport(data, format, a.Repository.UnmarshalJsonMenu, menuImportColumns, menuItemFromRecord)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+
+	menuItems, err := a.getMenuItems()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	inventoryItems, err := a.getInventoryItems()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	// Меню после импорта: компоненты комбо могут импортироваться в том же файле
+	combined := slices.Clone(menuItems)
+	for _, item := range items {
+		if item == nil {
+			continue
+		}
+		if i := slices.IndexFunc(combined, func(existing *domain.MenuItem) bool { return existing.ID == item.ID }); i >= 0 {
+			combined[i] = item
+			continue
+		}
+		combined = append(combined, item)
+	}
+
+	result := newImportResult(options, rowErrors)
+	ids := make(map[string]int)
+	names := make(map[string]int)
+	for i, item := range items {
+		row := &result.Rows[i]
+		if item == nil {
+			continue
+		}
+		row.ID = item.ID
+
+		if err := CheckMenuItemFields(item); err != nil {
+			row.Errors = append(row.Errors, err.Error())
+		}
+		if err := checkBundleComponents(item, combined); err != nil {
+			row.Errors = append(row.Errors, err.Error())
+		}
+		if err := recipeUnitsError(item, inventoryItems); err != nil {
+			row.Errors = append(row.Errors, err.Error())
+		}
+		if first, ok := ids[item.ID]; ok && item.ID != "" {
+			row.Errors = append(row.Errors, fmt.Sprintf("duplicate product ID %s, first seen in row %d", item.ID, first))
+		}
+		if first, ok := names[item.Name]; ok && item.Name != "" {
+			row.Errors = append(row.Errors, fmt.Sprintf("duplicate name %s, first seen in row %d", item.Name, first))
+		}
+		ids[item.ID], names[item.Name] = row.Row, row.Row
+
+		row.Action = domain.ImportCreate
+		if findMenuItem(item.ID, menuItems) != nil {
+			row.Action = domain.ImportUpdate
+			if options.Mode == domain.ImportInsert {
+				row.Errors = append(row.Errors, fmt.Sprintf("menu item with ID %s already exists", item.ID))
+			}
+		}
+		for _, existing := range menuItems {
+			if existing.Name == item.Name && existing.ID != item.ID {
+				row.Errors = append(row.Errors, fmt.Sprintf("menu item with name %s already exists", item.Name))
+			}
+		}
+	}
+
+	if status := finishImport(result); status != http.StatusOK || options.DryRun {
+		return marshalImportResult(result, status)
+	}
+
+	// Применяем все строки разом
+	for _, item := range items {
+		if existing := findMenuItem(item.ID, menuItems); existing != nil {
+			*existing = *item
+			continue
+		}
+		menuItems = append(menuItems, item)
+	}
+
+	menuItemsJson, err := a.Repository.MarshalJsonMenuItems(menuItems)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if err := a.Repository.SaveMenuItems(menuItemsJson); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	for _, item := range items {
+		a.searchIndex.Remove(search.KindMenu, item.ID)
+		a.indexMenuItem(item)
+	}
+
+	result.Applied = true
+	return marshalImportResult(result, http.StatusOK)
+}
+
+// ImportInventoryItems импортирует ингредиенты из JSON-массива или CSV.
+// Новые ингредиенты приходуются, у обновляемых разница в количестве записывается корректировкой.
+func (a *Application) ImportInventoryItems(data []byte, format export.Format, options domain.ImportOptions, user string) ([]byte, int, error) {
+	a.Repository.Lock()
+	defer a.unlockAndNotify()
+
+	items, rowErrors, err := decodeImport(data, format, a.Repository.UnmarshalJsonInventory, inventoryImportColumns, inventoryItemFromRecord)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+
+	inventoryItems, err := a.getInventoryItems()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	menuItems, err := a.getMenuItems()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	result := newImportResult(options, rowErrors)
+	ids := make(map[string]int)
+	names := make(map[string]int)
+	for i, item := range items {
+		row := &result.Rows[i]
+		if item == nil {
+			continue
+		}
+		row.ID = item.IngredientID
+
+		if err := validateInventoryItem(item); err != nil {
+			row.Errors = append(row.Errors, err.Error())
+		}
+		if err := inventoryUnitUsageError(item, menuItems); err != nil {
+			row.Errors = append(row.Errors, err.Error())
+		}
+		if first, ok := ids[item.IngredientID]; ok && item.IngredientID != "" {
+			row.Errors = append(row.Errors, fmt.Sprintf("duplicate ingredient ID %s, first seen in row %d", item.IngredientID, first))
+		}
+		if first, ok := names[item.Name]; ok && item.Name != "" {
+			row.Errors = append(row.Errors, fmt.Sprintf("duplicate name %s, first seen in row %d", item.Name, first))
+		}
+		ids[item.IngredientID], names[item.Name] = row.Row, row.Row
+
+		row.Action = domain.ImportCreate
+		if findInventoryItem(item.IngredientID, inventoryItems) != nil {
+			row.Action = domain.ImportUpdate
+			if options.Mode == domain.ImportInsert {
+				row.Errors = append(row.Errors, fmt.Sprintf("inventory item with Ingredient ID %s already exists", item.IngredientID))
+			}
+		}
+		for _, existing := range inventoryItems {
+			if existing.Name == item.Name && existing.IngredientID != item.IngredientID {
+				row.Errors = append(row.Errors, fmt.Sprintf("inventory item with name %s already exists", item.Name))
+			}
+		}
+	}
+
+	if status := finishImport(result); status != http.StatusOK || options.DryRun {
+		return marshalImportResult(result, status)
+	}
+
+	// Применяем все строки разом и записываем движения остатков
+	movements := make([]*domain.StockMovement, 0, len(items))
+	for _, item := range items {
+		existing := findInventoryItem(item.IngredientID, inventoryItems)
+		if existing == nil {
+			inventoryItems = append(inventoryItems, item)
+			movements = append(movements, newMovement(item.IngredientID, domain.MovementRestock, item.Quantity, "item imported", "", user))
+			continue
+		}
+		if delta := item.Quantity - existing.Quantity; delta != 0 {
+			movements = append(movements, newMovement(item.IngredientID, domain.MovementAdjustment, delta, "item imported", "", user))
+		}
+		*existing = *item
+	}
+
+	if err := a.saveInventoryItems(inventoryItems); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	for _, item := range items {
+		a.searchIndex.Remove(search.KindInventory, item.IngredientID)
+		a.indexInventoryItem(item)
+	}
+	if err := a.recordMovements(movements...); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	a.evaluateStockLevels(inventoryItems)
+
+	result.Applied = true
+	return marshalImportResult(result, http.StatusOK)
+}
+
+// decodeImport разбирает записи импорта. Ошибка разбора отдельной записи возвращается для ее строки,
+// ошибка формата всего файла — общей ошибкой.
+func decodeImport[T any](data []byte, format export.Format, unmarshal func([]byte) (*T, error), columns []string, fromRecord func(map[string]string) (*T, error)) ([]*T, []error, error) {
+	var items []*T
+	var rowErrors []error
+	switch format {
+	case export.FormatJSON:
+		var raws []json.RawMessage
+		if err := json.Unmarshal(data, &raws); err != nil {
+			return nil, nil, errors.New("invalid import data: expected a JSON array")
+		}
+		for _, raw := range raws {
+			item, err := unmarshal(raw)
+			if err != nil {
+				err = errors.New("invalid record data")
+			}
+			items, rowErrors = append(items, item), append(rowErrors, err)
+		}
+	case export.FormatCSV:
+		header, records, err := export.ReadCSV(bytes.NewReader(data))
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid import data: %w", err)
+		}
+		for _, column := range header {
+			if !slices.Contains(columns, column) {
+				return nil, nil, fmt.Errorf("unknown column %q, expected %s", column, strings.Join(columns, ", "))
+			}
+		}
+		for _, record := range records {
+			item, err := fromRecord(record)
+			items, rowErrors = append(items, item), append(rowErrors, err)
+		}
+	default:
+		return nil, nil, fmt.Errorf("unsupported import format %s", format)
+	}
+
+	if len(items) == 0 {
+		return nil, nil, errors.New("import contains no records")
+	}
+	for i, err := range rowErrors {
+		if err != nil {
+			items[i] = nil
+		}
+	}
+	return items, rowErrors, nil
+}
+
+// menuItemFromRecord собирает позицию меню из строки CSV.
+// Состав записывается как "ingredient_id quantity [unit]; ...", компоненты комбо — как "product_id quantity; category:name quantity; ...".
+func menuItemFromRecord(record map[string]string) (*domain.MenuItem, error) {
+	item := &domain.MenuItem{
+		ID:          record["product_id"],
+		Name:        record["name"],
+		Description: record["description"],
+		Category:    record["category"],
+		Ingredients: make([]domain.MenuItemIngredient, 0),
+	}
+
+	var err error
+	if item.Price, err = parseImportNumber(record, "price"); err != nil {
+		return nil, err
+	}
+
+	for _, part := range strings.Split(record["ingredients"], ";") {
+		fields := strings.Fields(part)
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) > 3 || len(fields) < 2 {
+			return nil, fmt.Errorf("invalid ingredient %q, expected \"ingredient_id quantity [unit]\"", strings.TrimSpace(part))
+		}
+		quantity, err := strconv.ParseFloat(fields[1], 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid quantity %q for ingredient %s", fields[1], fields[0])
+		}
+		ingredient := domain.MenuItemIngredient{IngredientID: fields[0], Quantity: quantity}
+		if len(fields) == 3 {
+			ingredient.Unit = fields[2]
+		}
+		item.Ingredients = append(item.Ingredients, ingredient)
+	}
+
+	for _, part := range strings.Split(record["components"], ";") {
+		fields := strings.Fields(part)
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("invalid component %q, expected \"product_id quantity\" or \"category:name quantity\"", strings.TrimSpace(part))
+		}
+		quantity, err := strconv.Atoi(fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid quantity %q for component %s", fields[1], fields[0])
+		}
+		component := domain.BundleComponent{ProductID: fields[0], Quantity: quantity}
+		if category, ok := strings.CutPrefix(fields[0], "category:"); ok {
+			component = domain.BundleComponent{Category: category, Quantity: quantity}
+		}
+		item.Components = append(item.Components, component)
+	}
+	return item, nil
+}
+
+// inventoryItemFromRecord собирает ингредиент из строки CSV; колонка low_stock вычисляемая и не читается
+func inventoryItemFromRecord(record map[string]string) (*domain.InventoryItem, error) {
+	item := &domain.InventoryItem{
+		IngredientID: record["ingredient_id"],
+		Name:         record["name"],
+		Unit:         record["unit"],
+	}
+
+	var err error
+	if item.Quantity, err = parseImportNumber(record, "quantity"); err != nil {
+		return nil, err
+	}
+	if item.ReorderPoint, err = parseImportNumber(record, "reorder_point"); err != nil {
+		return nil, err
+	}
+	if item.ParLevel, err = parseImportNumber(record, "par_level"); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// parseImportNumber читает число из колонки; пустое значение равно нулю
+func parseImportNumber(record map[string]string, column string) (float64, error) {
+	value := record[column]
+	if value == "" {
+		return 0, nil
+	}
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q", column, value)
+	}
+	return number, nil
+}
+
+func newImportResult(options domain.ImportOptions, rowErrors []error) *domain.ImportResult {
+	result := &domain.ImportResult{
+		Mode:   options.Mode,
+		DryRun: options.DryRun,
+		Total:  len(rowErrors),
+		Rows:   make([]domain.ImportRowResult, len(rowErrors)),
+	}
+	for i, err := range rowErrors {
+		result.Rows[i].Row = i + 1
+		if err != nil {
+			result.Rows[i].Errors = []string{err.Error()}
+		}
+	}
+	return result
+}
+
+// finishImport подсчитывает итоги проверки; при ошибках в строках импорт отклоняется целиком
+func finishImport(result *domain.ImportResult) int {
+	for _, row := range result.Rows {
+		switch {
+		case len(row.Errors) > 0:
+			result.Failed++
+		case row.Action == domain.ImportCreate:
+			result.Created++
+		case row.Action == domain.ImportUpdate:
+			result.Updated++
+		}
+	}
+	if result.Failed > 0 {
+		return http.StatusUnprocessableEntity
+	}
+	return http.StatusOK
+}
+
+func marshalImportResult(result *domain.ImportResult, status int) ([]byte, int, error) {
+	data, err := json.Marshal(result)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	return data, status, nil
+}
@@ -0,0 +1,151 @@
+package usecase
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"hot-coffee/internal/domain"
+)
+
+// GetIngredientUsage раскладывает завершенные за период [from, to) заказы по текущим рецептам
+// и сравнивает ожидаемый расход ингредиентов со списаниями в журнале движений за тот же период.
+// Ингредиент отмечается, если необъяснимое расхождение превышает threshold процентов от ожидаемого расхода.
+func (a *Application) GetIngredientUsage(from, to time.Time, threshold float64) (*domain.IngredientUsageReport, error) {
+	orders, err := a.getOrders()
+	if err != nil {
+		return nil, err
+	}
+
+	menuItems, err := a.getMenuItems()
+	if err != nil {
+		return nil, err
+	}
+
+	inventoryItems, err := a.getInventoryItems()
+	if err != nil {
+		return nil, err
+	}
+
+	ledger, err := a.getMovements()
+	if err != nil {
+		return nil, err
+	}
+
+	byIngredient := make(map[string]*domain.IngredientUsage)
+	byProduct := make(map[string]map[string]*domain.ProductIngredientUsage)
+	usage := func(ingredientID string) *domain.IngredientUsage {
+		item, ok := byIngredient[ingredientID]
+		if !ok {
+			item = &domain.IngredientUsage{IngredientID: ingredientID}
+			if inventoryItem := findInventoryItem(ingredientID, inventoryItems); inventoryItem != nil {
+				item.Name = inventoryItem.Name
+				item.Unit = inventoryItem.Unit
+			}
+			byIngredient[ingredientID] = item
+			byProduct[ingredientID] = make(map[string]*domain.ProductIngredientUsage)
+		}
+		return item
+	}
+
+	// Ожидаемый расход по рецептам; заказы относятся к периоду по времени закрытия, как и списания в журнале.
+	// Возвращенные заказы, ингредиенты которых не вернулись на склад, остаются израсходованными
+	for _, order := range orders {
+		consumed := order.Status == domain.StatusCompleted ||
+			order.Status == domain.StatusRefunded && order.Refund != nil && !order.Refund.Restock
+		if !consumed || !inPeriod(completedAt(order), from, to) {
+			continue
+		}
+		for _, orderItem := range order.Items {
+			menuItem := findMenuItem(orderItem.ProductID, menuItems)
+			if menuItem == nil {
+				continue
+			}
+			recipe, err := orderItemRecipe(orderItem, menuItem, menuItems)
+			if err != nil {
+				continue
+			}
+			for _, ingredient := range recipe {
+				quantity := ingredient.Quantity * float64(orderItem.Quantity)
+				if inventoryItem := findInventoryItem(ingredient.IngredientID, inventoryItems); inventoryItem != nil {
+					if converted, err := requiredQuantity(ingredient, orderItem.Quantity, inventoryItem); err == nil {
+						quantity = converted
+					}
+				}
+
+				item := usage(ingredient.IngredientID)
+				item.ExpectedQuantity += quantity
+
+				product, ok := byProduct[ingredient.IngredientID][menuItem.ID]
+				if !ok {
+					product = &domain.ProductIngredientUsage{ProductID: menuItem.ID, Name: menuItem.Name}
+					byProduct[ingredient.IngredientID][menuItem.ID] = product
+				}
+				product.ItemsSold += orderItem.Quantity
+				product.Quantity += quantity
+			}
+		}
+	}
+
+	// Фактические списания по журналу
+	for _, movement := range ledger {
+		if !inPeriod(movement.CreatedAt, from, to) {
+			continue
+		}
+		switch movement.Type {
+		case domain.MovementSale:
+			usage(movement.IngredientID).SoldQuantity -= movement.Delta
+		case domain.MovementReturn:
+			usage(movement.IngredientID).ReturnedQuantity += movement.Delta
+		case domain.MovementWaste:
+			usage(movement.IngredientID).WastedQuantity -= movement.Delta
+		case domain.MovementAdjustment:
+			usage(movement.IngredientID).AdjustedQuantity -= movement.Delta
+		}
+	}
+
+	report := &domain.IngredientUsageReport{
+		From:              optionalTime(from),
+		To:                optionalTime(to),
+		VarianceThreshold: threshold,
+		Items:             make([]domain.IngredientUsage, 0, len(byIngredient)),
+	}
+	for ingredientID, item := range byIngredient {
+		item.ActualQuantity = item.SoldQuantity - item.ReturnedQuantity + item.WastedQuantity + item.AdjustedQuantity
+		item.Variance = item.ActualQuantity - item.ExpectedQuantity - item.WastedQuantity
+		if math.Abs(item.Variance) <= driftEpsilon {
+			item.Variance = 0
+		}
+		if item.ExpectedQuantity > 0 {
+			percent := item.Variance / item.ExpectedQuantity * 100
+			item.VariancePercent = &percent
+			item.Flagged = math.Abs(percent) > threshold
+		} else {
+			item.Flagged = item.Variance != 0
+		}
+
+		item.ByProduct = make([]domain.ProductIngredientUsage, 0, len(byProduct[ingredientID]))
+		for _, product := range byProduct[ingredientID] {
+			item.ByProduct = append(item.ByProduct, *product)
+		}
+		sort.Slice(item.ByProduct, func(i, j int) bool {
+			if item.ByProduct[i].Quantity != item.ByProduct[j].Quantity {
+				return item.ByProduct[i].Quantity > item.ByProduct[j].Quantity
+			}
+			return item.ByProduct[i].ProductID < item.ByProduct[j].ProductID
+		})
+		report.Items = append(report.Items, *item)
+	}
+	sort.Slice(report.Items, func(i, j int) bool {
+		return report.Items[i].IngredientID < report.Items[j].IngredientID
+	})
+	return report, nil
+}
+
+// completedAt возвращает время закрытия заказа; у заказов, закрытых до появления этого поля, — время создания
+func completedAt(order *domain.Order) time.Time {
+	if order.CompletedAt != nil {
+		return *order.CompletedAt
+	}
+	return order.CreatedAt
+}
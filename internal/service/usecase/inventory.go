@@ -6,9 +6,13 @@ import (
 	"net/http"
 
 	"hot-coffee/internal/domain"
+	"hot-coffee/internal/search"
 )
 
-func (a *Application) AddInventoryItem(data []byte) (int, error) {
+func (a *Application) AddInventoryItem(data []byte, user string) (int, error) {
+	a.Repository.Lock()
+	defer a.unlockAndNotify()
+
 	item, err := a.Repository.UnmarshalJsonInventory(data)
 	if err != nil {
 		return http.StatusBadRequest, fmt.Errorf("invalid inventory item data")
@@ -18,6 +22,10 @@ func (a *Application) AddInventoryItem(data []byte) (int, error) {
 		return http.StatusBadRequest, err
 	}
 
+	if err := a.checkInventoryUnitUsage(item); err != nil {
+		return http.StatusBadRequest, err
+	}
+
 	allData, err := a.Repository.GetInventoryItems()
 	if err != nil {
 		return http.StatusInternalServerError, err
@@ -49,20 +57,32 @@ func (a *Application) AddInventoryItem(data []byte) (int, error) {
 	if err := a.Repository.SaveInventoryItems(updatedData); err != nil {
 		return http.StatusInternalServerError, err
 	}
+	a.indexInventoryItem(item)
+
+	if err := a.recordMovements(newMovement(item.IngredientID, domain.MovementRestock, item.Quantity, "item added", "", user)); err != nil {
+		return http.StatusInternalServerError, err
+	}
+	a.evaluateStockLevels(inventoryItems)
 
 	return http.StatusOK, nil
 }
 
-func (a *Application) GetAllInventoryItems() ([]byte, int, error) {
-	data, err := a.Repository.GetInventoryItems()
+// GetAllInventoryItems возвращает страницу ингредиентов, подходящих под фильтр
+func (a *Application) GetAllInventoryItems(filter domain.InventoryFilter, options domain.ListOptions) ([]byte, domain.PageInfo, int, error) {
+	inventoryItems, err := a.getInventoryItems()
 	if err != nil {
-		return nil, http.StatusNoContent, err
+		return nil, domain.PageInfo{}, http.StatusInternalServerError, err
 	}
 
-	if len(data) <= 2 {
-		return nil, http.StatusNotFound, fmt.Errorf("no inventory items found")
+	page, info, err := listItems(inventoryItems, filter.Matches, inventorySorts, options)
+	if err != nil {
+		return nil, domain.PageInfo{}, http.StatusBadRequest, err
+	}
+	data, err := a.Repository.MarshalInventoryItems(page)
+	if err != nil {
+		return nil, domain.PageInfo{}, http.StatusInternalServerError, err
 	}
-	return data, http.StatusOK, nil
+	return data, info, http.StatusOK, nil
 }
 
 func (a *Application) GetInventoryItemByID(id string) ([]byte, int, error) {
@@ -89,7 +109,10 @@ func (a *Application) GetInventoryItemByID(id string) ([]byte, int, error) {
 	return nil, http.StatusNotFound, fmt.Errorf("inventory item with Ingredient ID %s not found", id)
 }
 
-func (a *Application) UpdateInventoryItemByID(id string, data []byte) (int, error) {
+func (a *Application) UpdateInventoryItemByID(id string, data []byte, user string) (int, error) {
+	a.Repository.Lock()
+	defer a.unlockAndNotify()
+
 	inventory, err := a.Repository.UnmarshalJsonInventory(data)
 	if err != nil {
 		return http.StatusBadRequest, fmt.Errorf("invalid inventory item data")
@@ -99,6 +122,10 @@ func (a *Application) UpdateInventoryItemByID(id string, data []byte) (int, erro
 		return http.StatusBadRequest, err
 	}
 
+	if err = a.checkInventoryUnitUsage(inventory); err != nil {
+		return http.StatusBadRequest, err
+	}
+
 	allData, err := a.Repository.GetInventoryItems()
 	if err != nil {
 		return http.StatusInternalServerError, err
@@ -109,8 +136,14 @@ func (a *Application) UpdateInventoryItemByID(id string, data []byte) (int, erro
 		return http.StatusInternalServerError, err
 	}
 
+	var movements []*domain.StockMovement
+	updated := false
 	for i, item := range inventoryItems {
 		if item.IngredientID == id {
+			updated = true
+			if delta := inventory.Quantity - item.Quantity; delta != 0 {
+				movements = append(movements, newMovement(id, domain.MovementAdjustment, delta, "item updated", "", user))
+			}
 			inventoryItems[i] = inventory
 			break
 		}
@@ -125,11 +158,23 @@ func (a *Application) UpdateInventoryItemByID(id string, data []byte) (int, erro
 	if err != nil {
 		return http.StatusInternalServerError, err
 	}
+	if updated {
+		a.searchIndex.Remove(search.KindInventory, id)
+		a.indexInventoryItem(inventory)
+	}
+
+	if err = a.recordMovements(movements...); err != nil {
+		return http.StatusInternalServerError, err
+	}
+	a.evaluateStockLevels(inventoryItems)
 
 	return http.StatusOK, nil
 }
 
-func (a *Application) DeleteInventoryItemByID(id string) (int, error) {
+func (a *Application) DeleteInventoryItemByID(id, user string) (int, error) {
+	a.Repository.Lock()
+	defer a.unlockAndNotify()
+
 	allData, err := a.Repository.GetInventoryItems()
 	if err != nil {
 		return http.StatusInternalServerError, err
@@ -144,8 +189,12 @@ func (a *Application) DeleteInventoryItemByID(id string) (int, error) {
 		return http.StatusInternalServerError, err
 	}
 
+	var movements []*domain.StockMovement
 	for i, item := range inventoryItems {
 		if item.IngredientID == id {
+			if item.Quantity != 0 {
+				movements = append(movements, newMovement(id, domain.MovementAdjustment, -item.Quantity, "item deleted", "", user))
+			}
 			inventoryItems = append(inventoryItems[:i], inventoryItems[i+1:]...)
 			break
 		}
@@ -160,6 +209,12 @@ func (a *Application) DeleteInventoryItemByID(id string) (int, error) {
 	if err != nil {
 		return http.StatusInternalServerError, err
 	}
+	a.searchIndex.Remove(search.KindInventory, id)
+
+	if err = a.recordMovements(movements...); err != nil {
+		return http.StatusInternalServerError, err
+	}
+	a.evaluateStockLevels(inventoryItems)
 
 	return http.StatusNoContent, nil
 }
@@ -177,5 +232,46 @@ func validateInventoryItem(item *domain.InventoryItem) error {
 	if item.Unit == "" {
 		return fmt.Errorf("unit is required")
 	}
+	if _, ok := domain.LookupUnit(item.Unit); !ok {
+		return fmt.Errorf("unknown unit %q", item.Unit)
+	}
+	if item.ReorderPoint < 0 {
+		return fmt.Errorf("reorder point must not be negative")
+	}
+	if item.ParLevel < 0 {
+		return fmt.Errorf("par level must not be negative")
+	}
+	if item.ParLevel > 0 && item.ParLevel <= item.ReorderPoint {
+		return fmt.Errorf("par level must be greater than reorder point")
+	}
+	return nil
+}
+
+// checkInventoryUnitUsage проверяет, что рецепты меню совместимы с единицей измерения ингредиента
+func (a *Application) checkInventoryUnitUsage(item *domain.InventoryItem) error {
+	menuData, err := a.Repository.GetMenuItems()
+	if err != nil {
+		return err
+	}
+
+	menuItems, err := a.Repository.UnmarshalJsonMenuItems(menuData)
+	if err != nil {
+		return err
+	}
+	return inventoryUnitUsageError(item, menuItems)
+}
+
+// inventoryUnitUsageError сверяет единицу измерения ингредиента с рецептами переданных позиций меню
+func inventoryUnitUsageError(item *domain.InventoryItem, menuItems []*domain.MenuItem) error {
+	for _, menuItem := range menuItems {
+		for _, ingredient := range menuItem.Ingredients {
+			if ingredient.IngredientID != item.IngredientID || ingredient.Unit == "" {
+				continue
+			}
+			if err := domain.CompatibleUnits(ingredient.Unit, item.Unit); err != nil {
+				return fmt.Errorf("menu item %s uses ingredient %s: %w", menuItem.ID, item.IngredientID, err)
+			}
+		}
+	}
 	return nil
 }
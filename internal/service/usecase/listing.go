@@ -0,0 +1,70 @@
+package usecase
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	"hot-coffee/internal/domain"
+)
+
+// Допустимые поля сортировки списков
+var (
+	orderSorts = map[string]func(a, b *domain.Order) int{
+		"created_at": func(a, b *domain.Order) int { return a.CreatedAt.Compare(b.CreatedAt) },
+		"customer_name": func(a, b *domain.Order) int {
+			return cmp.Compare(strings.ToLower(a.CustomerName), strings.ToLower(b.CustomerName))
+		},
+	}
+	menuSorts = map[string]func(a, b *domain.MenuItem) int{
+		"product_id": func(a, b *domain.MenuItem) int { return cmp.Compare(a.ID, b.ID) },
+		"name":       func(a, b *domain.MenuItem) int { return cmp.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name)) },
+		"price":      func(a, b *domain.MenuItem) int { return cmp.Compare(a.Price, b.Price) },
+	}
+	inventorySorts = map[string]func(a, b *domain.InventoryItem) int{
+		"ingredient_id": func(a, b *domain.InventoryItem) int { return cmp.Compare(a.IngredientID, b.IngredientID) },
+		"name": func(a, b *domain.InventoryItem) int {
+			return cmp.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+		},
+		"quantity": func(a, b *domain.InventoryItem) int { return cmp.Compare(a.Quantity, b.Quantity) },
+	}
+)
+
+// listItems фильтрует, сортирует и разбивает на страницы список.
+// Возвращает пустой, а не nil, срез, если ничего не найдено.
+func listItems[T any](items []*T, matches func(*T) bool, sorts map[string]func(a, b *T) int, options domain.ListOptions) ([]*T, domain.PageInfo, error) {
+	if _, ok := sorts[options.Sort]; options.Sort != "" && !ok {
+		fields := make([]string, 0, len(sorts))
+		for field := range sorts {
+			fields = append(fields, field)
+		}
+		slices.Sort(fields)
+		return nil, domain.PageInfo{}, fmt.Errorf("invalid sort: %q, expected one of %s", options.Sort, strings.Join(fields, ", "))
+	}
+
+	result := make([]*T, 0, len(items))
+	for _, item := range items {
+		if matches(item) {
+			result = append(result, item)
+		}
+	}
+
+	if compare, ok := sorts[options.Sort]; ok {
+		slices.SortStableFunc(result, func(a, b *T) int {
+			if options.Desc {
+				return compare(b, a)
+			}
+			return compare(a, b)
+		})
+	}
+
+	info := domain.PageInfo{Page: max(options.Page, 1), PerPage: options.PerPage, Total: len(result)}
+	if options.PerPage <= 0 {
+		return result, info, nil
+	}
+
+	start := min((info.Page-1)*options.PerPage, len(result))
+	end := min(start+options.PerPage, len(result))
+	return result[start:end], info, nil
+}
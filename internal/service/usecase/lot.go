@@ -0,0 +1,282 @@
+package usecase
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"hot-coffee/internal/domain"
+)
+
+// applyLots отражает движения по складу в партиях и рассчитывает их стоимость:
+// пополнения и возвраты заказов создают новую партию, списания расходуют партии в порядке FEFO. Продажи не расходуют просроченные партии.
+// Стоимость списаний не зависит от физического порядка расхода партий: FIFO считается по слоям стоимости
+// в порядке поступления, средняя — по средней себестоимости остатка.
+func (a *Application) applyLots(movements []*domain.StockMovement, values map[string]*stockValue) error {
+	lots, err := a.getLots()
+	if err != nil {
+		return err
+	}
+
+	changed := false
+	for _, movement := range movements {
+		value := values[movement.IngredientID]
+		if value == nil {
+			value = &stockValue{}
+			values[movement.IngredientID] = value
+		}
+
+		switch {
+		case movement.Delta > 0:
+			if movement.UnitCost == 0 {
+				movement.UnitCost = value.unitCost()
+			}
+			// Возврат заказа восстанавливает ровно ту стоимость, которая была списана при продаже
+			if movement.Type != domain.MovementReturn || movement.Cost == 0 {
+				movement.Cost = movement.Delta * movement.UnitCost
+			}
+			if movement.Type == domain.MovementRestock || movement.Type == domain.MovementReturn {
+				lot := &domain.InventoryLot{
+					ID:           generateID("LOT"),
+					IngredientID: movement.IngredientID,
+					Quantity:     movement.Delta,
+					ReceivedAt:   movement.CreatedAt,
+					ExpiresAt:    movement.ExpiresAt,
+					UnitCost:     movement.UnitCost,
+				}
+				movement.LotID = lot.ID
+				lots = append(lots, lot)
+				changed = true
+			}
+		case movement.Delta < 0:
+			quantity := -movement.Delta
+			var expiredBy time.Time
+			if movement.Type == domain.MovementSale {
+				expiredBy = movement.CreatedAt
+			}
+			consumeLots(lots, movement.IngredientID, movement.LotID, quantity, expiredBy)
+			if a.costingMethod() == domain.CostingFIFO {
+				movement.Cost = -value.fifoCostOf(quantity)
+			} else {
+				movement.Cost = -value.costOf(quantity)
+			}
+			movement.UnitCost = -movement.Cost / quantity
+			changed = true
+		}
+		value.apply(movement)
+	}
+
+	if !changed {
+		return nil
+	}
+	return a.saveLots(pruneLots(lots))
+}
+
+// consumeLots уменьшает партии ингредиента на quantity.
+// Если указан lotID, сначала расходуется эта партия; при ненулевом expiredBy партии, просроченные к этому моменту, пропускаются.
+// Остаток, не покрытый партиями (например, заведенный до учета партий), в партиях не отражается.
+func consumeLots(lots []*domain.InventoryLot, ingredientID, lotID string, quantity float64, expiredBy time.Time) {
+	candidates := make([]*domain.InventoryLot, 0)
+	for _, lot := range lots {
+		if !expiredBy.IsZero() && lot.IsExpired(expiredBy) {
+			continue
+		}
+		if lot.IngredientID == ingredientID && lot.Quantity > 0 {
+			candidates = append(candidates, lot)
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if lotID != "" && (candidates[i].ID == lotID) != (candidates[j].ID == lotID) {
+			return candidates[i].ID == lotID
+		}
+		return candidates[i].ConsumesBefore(candidates[j])
+	})
+
+	for _, lot := range candidates {
+		if quantity <= 0 {
+			break
+		}
+		taken := min(lot.Quantity, quantity)
+		lot.Quantity -= taken
+		quantity -= taken
+	}
+}
+
+// pruneLots удаляет полностью израсходованные партии
+func pruneLots(lots []*domain.InventoryLot) []*domain.InventoryLot {
+	result := make([]*domain.InventoryLot, 0, len(lots))
+	for _, lot := range lots {
+		if lot.Quantity > driftEpsilon {
+			result = append(result, lot)
+		}
+	}
+	return result
+}
+
+// GetInventoryLots возвращает партии ингредиента в порядке списания
+func (a *Application) GetInventoryLots(id string) ([]byte, int, error) {
+	inventoryItems, err := a.getInventoryItems()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if findInventoryItem(id, inventoryItems) == nil {
+		return nil, http.StatusNotFound, fmt.Errorf("inventory item with Ingredient ID %s not found", id)
+	}
+
+	lots, err := a.getLots()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	result := make([]*domain.InventoryLot, 0)
+	for _, lot := range lots {
+		if lot.IngredientID == id {
+			result = append(result, lot)
+		}
+	}
+	sortLots(result)
+
+	data, err := a.Repository.MarshalJsonLots(result)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	return data, http.StatusOK, nil
+}
+
+// GetExpiringLots возвращает партии, срок годности которых истекает в течение within (включая уже истекшие)
+func (a *Application) GetExpiringLots(within time.Duration) ([]byte, int, error) {
+	lots, err := a.getLots()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	deadline := time.Now().Add(within)
+	result := make([]*domain.InventoryLot, 0)
+	for _, lot := range lots {
+		if lot.IsExpired(deadline) {
+			result = append(result, lot)
+		}
+	}
+	sortLots(result)
+
+	data, err := a.Repository.MarshalJsonLots(result)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	return data, http.StatusOK, nil
+}
+
+// WriteOffExpiredLots списывает в отходы все партии с истекшим сроком годности.
+// Возвращает количество списанных партий.
+func (a *Application) WriteOffExpiredLots() (int, error) {
+	a.Repository.Lock()
+	defer a.unlockAndNotify()
+
+	lots, err := a.getLots()
+	if err != nil {
+		return 0, err
+	}
+
+	inventoryItems, err := a.getInventoryItems()
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+	movements := make([]*domain.StockMovement, 0)
+	for _, lot := range lots {
+		if !lot.IsExpired(now) || lot.Quantity <= 0 {
+			continue
+		}
+		inventoryItem := findInventoryItem(lot.IngredientID, inventoryItems)
+		if inventoryItem == nil {
+			continue
+		}
+
+		quantity := min(lot.Quantity, inventoryItem.Quantity)
+		if quantity <= 0 {
+			continue
+		}
+		inventoryItem.Quantity -= quantity
+		movement := newMovement(lot.IngredientID, domain.MovementWaste, -quantity, fmt.Sprintf("lot %s expired", lot.ID), "", "")
+		movement.LotID = lot.ID
+		movement.WasteReason = domain.WasteExpired
+		movements = append(movements, movement)
+	}
+
+	if len(movements) == 0 {
+		return 0, nil
+	}
+
+	if err := a.saveInventoryItems(inventoryItems); err != nil {
+		return 0, err
+	}
+	if err := a.recordMovements(movements...); err != nil {
+		return 0, err
+	}
+	a.evaluateStockLevels(inventoryItems)
+
+	return len(movements), nil
+}
+
+// RunExpiryWriteOff списывает просроченные партии при запуске и затем периодически
+func (a *Application) RunExpiryWriteOff(interval time.Duration) {
+	a.writeOffExpired()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		a.writeOffExpired()
+	}
+}
+
+func (a *Application) writeOffExpired() {
+	count, err := a.WriteOffExpiredLots()
+	if err != nil {
+		a.logger().Printf("Failed to write off expired lots: %v", err)
+		return
+	}
+	if count > 0 {
+		a.logger().Printf("Wrote off %d expired lots", count)
+	}
+}
+
+// expiredStock возвращает количество ингредиентов в партиях, просроченных к моменту now.
+// Такой остаток ждет списания и не может быть продан.
+func (a *Application) expiredStock(now time.Time) (map[string]float64, error) {
+	lots, err := a.getLots()
+	if err != nil {
+		return nil, err
+	}
+	expired := make(map[string]float64)
+	for _, lot := range lots {
+		if lot.IsExpired(now) && lot.Quantity > 0 {
+			expired[lot.IngredientID] += lot.Quantity
+		}
+	}
+	return expired, nil
+}
+
+func sortLots(lots []*domain.InventoryLot) {
+	sort.SliceStable(lots, func(i, j int) bool {
+		return lots[i].ConsumesBefore(lots[j])
+	})
+}
+
+func (a *Application) getLots() ([]*domain.InventoryLot, error) {
+	data, err := a.Repository.GetLots()
+	if err != nil {
+		return nil, err
+	}
+	return a.Repository.UnmarshalJsonLots(data)
+}
+
+func (a *Application) saveLots(lots []*domain.InventoryLot) error {
+	data, err := a.Repository.MarshalJsonLots(lots)
+	if err != nil {
+		return err
+	}
+	return a.Repository.SaveLots(data)
+}
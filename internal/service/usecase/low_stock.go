@@ -0,0 +1,109 @@
+package usecase
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"hot-coffee/internal/domain"
+)
+
+// GetLowStockItems возвращает ингредиенты, остаток которых опустился до точки заказа
+func (a *Application) GetLowStockItems() ([]byte, int, error) {
+	data, err := a.Repository.GetInventoryItems()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	inventoryItems, err := a.Repository.UnmarshalInventoryItems(data)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	now := time.Now()
+	lowStock := make([]domain.LowStockItem, 0)
+	for _, item := range inventoryItems {
+		if item.IsLowStock() {
+			lowStock = append(lowStock, domain.NewLowStockItem(item, now))
+		}
+	}
+
+	result, err := json.Marshal(lowStock)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	return result, http.StatusOK, nil
+}
+
+// Размер очереди уведомлений о низком остатке
+const alertQueueSize = 64
+
+// evaluateStockLevels проверяет остатки после изменения инвентаря и откладывает уведомления до снятия блокировки репозитория.
+// Повторное уведомление по ингредиенту отправляется только после того, как остаток восстановится.
+// Отправленные уведомления помнятся только в памяти: после перезапуска низкие остатки уведомляются повторно.
+func (a *Application) evaluateStockLevels(inventoryItems []*domain.InventoryItem) {
+	now := time.Now()
+	present := make(map[string]bool, len(inventoryItems))
+
+	a.alertsMu.Lock()
+	defer a.alertsMu.Unlock()
+	for _, item := range inventoryItems {
+		present[item.IngredientID] = true
+
+		if !item.IsLowStock() {
+			delete(a.alerted, item.IngredientID)
+			continue
+		}
+		if a.alerted[item.IngredientID] {
+			continue
+		}
+		a.alerted[item.IngredientID] = true
+		alert := domain.NewLowStockItem(item, now)
+		a.logger().Printf("Low stock: %s (%s) is at %.2f %s, reorder point %.2f",
+			alert.Name, alert.IngredientID, alert.Quantity, alert.Unit, alert.ReorderPoint)
+		a.pendingAlerts = append(a.pendingAlerts, alert)
+	}
+
+	// Удаленные ингредиенты больше не отслеживаются
+	for id := range a.alerted {
+		if !present[id] {
+			delete(a.alerted, id)
+		}
+	}
+}
+
+// unlockAndNotify снимает блокировку репозитория и передает отложенные уведомления в очередь отправки.
+// Медленный получатель не задерживает запросы: при переполненной очереди уведомление отбрасывается
+// и будет отправлено при следующей проверке остатков.
+func (a *Application) unlockAndNotify() {
+	a.Repository.Unlock()
+
+	a.alertsMu.Lock()
+	defer a.alertsMu.Unlock()
+	alerts := a.pendingAlerts
+	a.pendingAlerts = nil
+
+	if a.Notifier == nil {
+		return
+	}
+	for _, alert := range alerts {
+		select {
+		case a.alerts <- alert:
+		default:
+			delete(a.alerted, alert.IngredientID)
+			a.logger().Printf("Low stock alert queue is full, dropped alert for %s", alert.IngredientID)
+		}
+	}
+}
+
+// RunNotifier отправляет уведомления о низком остатке из очереди. Запускается отдельной горутиной.
+func (a *Application) RunNotifier() {
+	if a.Notifier == nil {
+		return
+	}
+	for alert := range a.alerts {
+		if err := a.Notifier.Notify(alert); err != nil {
+			a.logger().Printf("Failed to send low stock alert for %s: %v", alert.IngredientID, err)
+		}
+	}
+}
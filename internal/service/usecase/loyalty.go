@@ -0,0 +1,576 @@
+package usecase
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"slices"
+	"time"
+
+	"hot-coffee/internal/domain"
+)
+
+func (a *Application) AddLoyaltyRule(data []byte) (int, error) {
+	rule, err := a.Repository.UnmarshalJsonLoyaltyRule(data)
+	if err != nil {
+		return http.StatusBadRequest, fmt.Errorf("invalid loyalty rule data")
+	}
+
+	a.Repository.Lock()
+	defer a.Repository.Unlock()
+
+	if err := a.validateLoyaltyRule(rule); err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	rules, err := a.getLoyaltyRules()
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	if findLoyaltyRule(rule.ID, rules) != nil {
+		return http.StatusConflict, fmt.Errorf("loyalty rule with ID %s already exists", rule.ID)
+	}
+
+	rules = append(rules, rule)
+	if err := a.saveLoyaltyRules(rules); err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	return http.StatusCreated, nil
+}
+
+func (a *Application) GetAllLoyaltyRules() ([]byte, int, error) {
+	rules, err := a.getLoyaltyRules()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	data, err := a.Repository.MarshalJsonLoyaltyRules(rules)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	return data, http.StatusOK, nil
+}
+
+func (a *Application) UpdateLoyaltyRuleByID(id string, data []byte) (int, error) {
+	rule, err := a.Repository.UnmarshalJsonLoyaltyRule(data)
+	if err != nil {
+		return http.StatusBadRequest, fmt.Errorf("invalid loyalty rule data")
+	}
+	rule.ID = id
+
+	a.Repository.Lock()
+	defer a.Repository.Unlock()
+
+	if err := a.validateLoyaltyRule(rule); err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	rules, err := a.getLoyaltyRules()
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	existing := findLoyaltyRule(id, rules)
+	if existing == nil {
+		return http.StatusNotFound, fmt.Errorf("loyalty rule with ID %s not found", id)
+	}
+	*existing = *rule
+
+	if err := a.saveLoyaltyRules(rules); err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	return http.StatusOK, nil
+}
+
+func (a *Application) DeleteLoyaltyRuleByID(id string) (int, error) {
+	a.Repository.Lock()
+	defer a.Repository.Unlock()
+
+	rules, err := a.getLoyaltyRules()
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	i := slices.IndexFunc(rules, func(rule *domain.EarnRule) bool { return rule.ID == id })
+	if i < 0 {
+		return http.StatusNotFound, fmt.Errorf("loyalty rule with ID %s not found", id)
+	}
+	rules = slices.Delete(rules, i, i+1)
+
+	if err := a.saveLoyaltyRules(rules); err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	return http.StatusNoContent, nil
+}
+
+func (a *Application) AddReward(data []byte) (int, error) {
+	reward, err := a.Repository.UnmarshalJsonReward(data)
+	if err != nil {
+		return http.StatusBadRequest, fmt.Errorf("invalid reward data")
+	}
+
+	a.Repository.Lock()
+	defer a.Repository.Unlock()
+
+	if err := a.validateReward(reward); err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	rewards, err := a.getRewards()
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	if findReward(reward.ID, rewards) != nil {
+		return http.StatusConflict, fmt.Errorf("reward with ID %s already exists", reward.ID)
+	}
+
+	rewards = append(rewards, reward)
+	if err := a.saveRewards(rewards); err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	return http.StatusCreated, nil
+}
+
+func (a *Application) GetAllRewards() ([]byte, int, error) {
+	rewards, err := a.getRewards()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	data, err := a.Repository.MarshalJsonRewards(rewards)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	return data, http.StatusOK, nil
+}
+
+// UpdateRewardByID изменяет награду. Уже оформленные заказы сохраняют условия, действовавшие при оформлении.
+func (a *Application) UpdateRewardByID(id string, data []byte) (int, error) {
+	reward, err := a.Repository.UnmarshalJsonReward(data)
+	if err != nil {
+		return http.StatusBadRequest, fmt.Errorf("invalid reward data")
+	}
+	reward.ID = id
+
+	a.Repository.Lock()
+	defer a.Repository.Unlock()
+
+	if err := a.validateReward(reward); err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	rewards, err := a.getRewards()
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	existing := findReward(id, rewards)
+	if existing == nil {
+		return http.StatusNotFound, fmt.Errorf("reward with ID %s not found", id)
+	}
+	*existing = *reward
+
+	if err := a.saveRewards(rewards); err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	return http.StatusOK, nil
+}
+
+func (a *Application) DeleteRewardByID(id string) (int, error) {
+	a.Repository.Lock()
+	defer a.Repository.Unlock()
+
+	rewards, err := a.getRewards()
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	i := slices.IndexFunc(rewards, func(reward *domain.Reward) bool { return reward.ID == id })
+	if i < 0 {
+		return http.StatusNotFound, fmt.Errorf("reward with ID %s not found", id)
+	}
+	rewards = slices.Delete(rewards, i, i+1)
+
+	if err := a.saveRewards(rewards); err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	return http.StatusNoContent, nil
+}
+
+// GetLoyaltyAccount возвращает баланс баллов клиента и его журнал, начиная с новых записей
+func (a *Application) GetLoyaltyAccount(customerID string) ([]byte, int, error) {
+	customers, err := a.getCustomers()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if findCustomer(customerID, customers) == nil {
+		return nil, http.StatusNotFound, fmt.Errorf("customer with ID %s not found", customerID)
+	}
+
+	ledger, err := a.getLoyaltyLedger()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return marshalLoyaltyAccount(customerID, ledger)
+}
+
+// AdjustLoyaltyPoints вручную начисляет или списывает баллы клиента. Баланс не может стать отрицательным.
+func (a *Application) AdjustLoyaltyPoints(customerID string, data []byte, user string) ([]byte, int, error) {
+	var adjustment domain.PointsAdjustment
+	if err := json.Unmarshal(data, &adjustment); err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("invalid adjustment data")
+	}
+	if adjustment.Points == 0 {
+		return nil, http.StatusBadRequest, errors.New("points must not be zero")
+	}
+	if adjustment.Reason == "" {
+		return nil, http.StatusBadRequest, errors.New("adjustment reason is required")
+	}
+
+	a.Repository.Lock()
+	defer a.Repository.Unlock()
+
+	customers, err := a.getCustomers()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if findCustomer(customerID, customers) == nil {
+		return nil, http.StatusNotFound, fmt.Errorf("customer with ID %s not found", customerID)
+	}
+
+	ledger, err := a.getLoyaltyLedger()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if balance := loyaltyBalance(customerID, ledger); balance+adjustment.Points < 0 {
+		return nil, http.StatusConflict, fmt.Errorf("customer %s has only %d points", customerID, balance)
+	}
+
+	ledger = append(ledger, newLoyaltyEntry(customerID, domain.LoyaltyAdjustment, adjustment.Points, "", adjustment.Reason, user))
+	if err := a.saveLoyaltyLedger(ledger); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return marshalLoyaltyAccount(customerID, ledger)
+}
+
+// redeemReward применяет к заказу награду клиента и возвращает записи журнала баллов для сохранения вместе с заказом.
+// При изменении заказа баллы за награду прежней версии previous возвращаются клиенту.
+func (a *Application) redeemReward(order, previous *domain.Order, menuItems []*domain.MenuItem) ([]*domain.LoyaltyEntry, int, error) {
+	entries := make([]*domain.LoyaltyEntry, 0, 2)
+	if previous != nil && previous.Reward != nil && previous.CustomerID != "" {
+		entry := newLoyaltyEntry(previous.CustomerID, domain.LoyaltyReversal, previous.Reward.Points, previous.ID, "order updated", "")
+		entry.RewardID = previous.Reward.RewardID
+		entries = append(entries, entry)
+	}
+	if order.Reward == nil {
+		return entries, http.StatusOK, nil
+	}
+	if order.CustomerID == "" {
+		return nil, http.StatusBadRequest, errors.New("reward requires a customer")
+	}
+
+	rewards, err := a.getRewards()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	reward := findReward(order.Reward.RewardID, rewards)
+	if reward == nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("reward %s not found", order.Reward.RewardID)
+	}
+
+	ledger, err := a.getLoyaltyLedger()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	balance := loyaltyBalance(order.CustomerID, append(ledger, entries...))
+	if balance < reward.Points {
+		return nil, http.StatusConflict, fmt.Errorf("customer %s has %d points, reward %s needs %d", order.CustomerID, balance, reward.ID, reward.Points)
+	}
+
+	var discount float64
+	switch reward.Type {
+	case domain.RewardFreeItem:
+		if !slices.ContainsFunc(order.Items, func(item domain.OrderItem) bool { return item.ProductID == reward.ProductID }) {
+			return nil, http.StatusBadRequest, fmt.Errorf("reward %s requires product %s in the order", reward.ID, reward.ProductID)
+		}
+		discount = findMenuItem(reward.ProductID, menuItems).Price
+	case domain.RewardDiscount:
+		discount = reward.Amount
+	}
+
+	// Скидка награды не превышает сумму заказа после скидок акций
+	order.Reward = nil
+	discount = min(discount, max(orderSubtotal(order, menuItems)-order.Discount(), 0))
+	order.Reward = &domain.RewardRedemption{
+		RewardID: reward.ID,
+		Name:     reward.Name,
+		Points:   reward.Points,
+		Discount: roundMoney(discount),
+	}
+	entry := newLoyaltyEntry(order.CustomerID, domain.LoyaltyRedeem, -reward.Points, order.ID, "reward redeemed", "")
+	entry.RewardID = reward.ID
+	return append(entries, entry), http.StatusOK, nil
+}
+
+// earnPoints начисляет клиенту баллы за завершенный заказ по всем правилам начисления
+func (a *Application) earnPoints(order *domain.Order, menuItems []*domain.MenuItem, user string) error {
+	if order.CustomerID == "" {
+		return nil
+	}
+
+	rules, err := a.getLoyaltyRules()
+	if err != nil {
+		return err
+	}
+
+	var points float64
+	for _, rule := range rules {
+		switch rule.Type {
+		case domain.EarnPerAmount:
+			points += rule.Points * (orderSubtotal(order, menuItems) - order.Discount())
+		case domain.EarnPerItem:
+			for _, item := range order.Items {
+				if len(rule.ProductIDs) == 0 || slices.Contains(rule.ProductIDs, item.ProductID) {
+					points += rule.Points * float64(item.Quantity)
+				}
+			}
+		}
+	}
+
+	// Поправка на погрешность вычислений с плавающей точкой перед округлением вниз
+	earned := int(math.Floor(points + 1e-9))
+	if earned <= 0 {
+		return nil
+	}
+	return a.recordLoyalty(newLoyaltyEntry(order.CustomerID, domain.LoyaltyEarn, earned, order.ID, "order completed", user))
+}
+
+// reverseOrderPoints отменяет все начисления и списания баллов по заказу.
+// Баланс клиента не уходит в минус: уже потраченная часть начисления остается, а недостача указывается в причине.
+func (a *Application) reverseOrderPoints(order *domain.Order, reason string) error {
+	if order.CustomerID == "" {
+		return nil
+	}
+
+	ledger, err := a.getLoyaltyLedger()
+	if err != nil {
+		return err
+	}
+
+	net := 0
+	for _, entry := range ledger {
+		if entry.OrderID == order.ID && entry.CustomerID == order.CustomerID {
+			net += entry.Points
+		}
+	}
+	if net == 0 {
+		return nil
+	}
+
+	// Начисленные баллы могли быть уже потрачены: списываем не больше текущего баланса
+	if balance := max(loyaltyBalance(order.CustomerID, ledger), 0); net > balance {
+		reason = fmt.Sprintf("%s; %d points already spent", reason, net-balance)
+		net = balance
+	}
+	return a.recordLoyalty(newLoyaltyEntry(order.CustomerID, domain.LoyaltyReversal, -net, order.ID, reason, ""))
+}
+
+// orderSubtotal считает сумму заказа до скидок по зафиксированным в нем ценам, а для старых заказов — по ценам меню
+func orderSubtotal(order *domain.Order, menuItems []*domain.MenuItem) float64 {
+	var subtotal float64
+	for _, item := range order.Items {
+		if price, ok := item.UnitPrice(menuLookup(menuItems)); ok {
+			subtotal += float64(item.Quantity) * price
+		}
+	}
+	return subtotal
+}
+
+func newLoyaltyEntry(customerID string, entryType domain.LoyaltyEntryType, points int, orderID, reason, user string) *domain.LoyaltyEntry {
+	return &domain.LoyaltyEntry{
+		ID:         generateID("LOY"),
+		CustomerID: customerID,
+		Type:       entryType,
+		Points:     points,
+		OrderID:    orderID,
+		Reason:     reason,
+		User:       user,
+		CreatedAt:  time.Now(),
+	}
+}
+
+func loyaltyBalance(customerID string, ledger []*domain.LoyaltyEntry) int {
+	balance := 0
+	for _, entry := range ledger {
+		if entry.CustomerID == customerID {
+			balance += entry.Points
+		}
+	}
+	return balance
+}
+
+func marshalLoyaltyAccount(customerID string, ledger []*domain.LoyaltyEntry) ([]byte, int, error) {
+	account := domain.LoyaltyAccount{CustomerID: customerID, Entries: make([]domain.LoyaltyEntry, 0)}
+	for _, entry := range slices.Backward(ledger) {
+		if entry.CustomerID == customerID {
+			account.Balance += entry.Points
+			account.Entries = append(account.Entries, *entry)
+		}
+	}
+
+	data, err := json.Marshal(account)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	return data, http.StatusOK, nil
+}
+
+// recordLoyalty дописывает записи в журнал баллов
+func (a *Application) recordLoyalty(entries ...*domain.LoyaltyEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	ledger, err := a.getLoyaltyLedger()
+	if err != nil {
+		return err
+	}
+	return a.saveLoyaltyLedger(append(ledger, entries...))
+}
+
+func (a *Application) getLoyaltyRules() ([]*domain.EarnRule, error) {
+	data, err := a.Repository.GetLoyaltyRules()
+	if err != nil {
+		return nil, err
+	}
+	return a.Repository.UnmarshalJsonLoyaltyRules(data)
+}
+
+func (a *Application) saveLoyaltyRules(rules []*domain.EarnRule) error {
+	data, err := a.Repository.MarshalJsonLoyaltyRules(rules)
+	if err != nil {
+		return err
+	}
+	return a.Repository.SaveLoyaltyRules(data)
+}
+
+func (a *Application) getRewards() ([]*domain.Reward, error) {
+	data, err := a.Repository.GetRewards()
+	if err != nil {
+		return nil, err
+	}
+	return a.Repository.UnmarshalJsonRewards(data)
+}
+
+func (a *Application) saveRewards(rewards []*domain.Reward) error {
+	data, err := a.Repository.MarshalJsonRewards(rewards)
+	if err != nil {
+		return err
+	}
+	return a.Repository.SaveRewards(data)
+}
+
+func (a *Application) getLoyaltyLedger() ([]*domain.LoyaltyEntry, error) {
+	data, err := a.Repository.GetLoyaltyLedger()
+	if err != nil {
+		return nil, err
+	}
+	return a.Repository.UnmarshalJsonLoyaltyLedger(data)
+}
+
+func (a *Application) saveLoyaltyLedger(entries []*domain.LoyaltyEntry) error {
+	data, err := a.Repository.MarshalJsonLoyaltyLedger(entries)
+	if err != nil {
+		return err
+	}
+	return a.Repository.SaveLoyaltyLedger(data)
+}
+
+func findLoyaltyRule(id string, rules []*domain.EarnRule) *domain.EarnRule {
+	for _, rule := range rules {
+		if rule.ID == id {
+			return rule
+		}
+	}
+	return nil
+}
+
+func findReward(id string, rewards []*domain.Reward) *domain.Reward {
+	for _, reward := range rewards {
+		if reward.ID == id {
+			return reward
+		}
+	}
+	return nil
+}
+
+// validateLoyaltyRule проверяет правило начисления и наличие его позиций в меню
+func (a *Application) validateLoyaltyRule(rule *domain.EarnRule) error {
+	if rule.ID == "" {
+		return errors.New("loyalty rule ID is required")
+	}
+	if !rule.Type.IsValid() {
+		return fmt.Errorf("invalid loyalty rule type: %q", rule.Type)
+	}
+	if rule.Points <= 0 {
+		return errors.New("points must be greater than zero")
+	}
+	if rule.Type != domain.EarnPerItem && len(rule.ProductIDs) > 0 {
+		return fmt.Errorf("product IDs apply only to %s rules", domain.EarnPerItem)
+	}
+
+	menuItems, err := a.getMenuItems()
+	if err != nil {
+		return err
+	}
+	for _, productID := range rule.ProductIDs {
+		if findMenuItem(productID, menuItems) == nil {
+			return fmt.Errorf("menu item %s not found", productID)
+		}
+	}
+	return nil
+}
+
+// validateReward проверяет награду; бесплатная позиция должна быть в меню
+func (a *Application) validateReward(reward *domain.Reward) error {
+	if reward.ID == "" {
+		return errors.New("reward ID is required")
+	}
+	if reward.Name == "" {
+		return errors.New("reward name is required")
+	}
+	if reward.Points <= 0 {
+		return errors.New("reward points must be greater than zero")
+	}
+
+	switch reward.Type {
+	case domain.RewardFreeItem:
+		if reward.ProductID == "" {
+			return fmt.Errorf("product ID is required for %s rewards", domain.RewardFreeItem)
+		}
+		menuItems, err := a.getMenuItems()
+		if err != nil {
+			return err
+		}
+		if findMenuItem(reward.ProductID, menuItems) == nil {
+			return fmt.Errorf("menu item %s not found", reward.ProductID)
+		}
+	case domain.RewardDiscount:
+		if reward.Amount <= 0 {
+			return errors.New("discount amount must be greater than zero")
+		}
+	default:
+		return fmt.Errorf("invalid reward type: %q", reward.Type)
+	}
+	return nil
+}
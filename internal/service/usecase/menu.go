@@ -6,9 +6,13 @@ import (
 	"net/http"
 
 	"hot-coffee/internal/domain"
+	"hot-coffee/internal/search"
 )
 
 func (a *Application) AddMenu(data []byte) (int, error) {
+	a.Repository.Lock()
+	defer a.Repository.Unlock()
+
 	// Unmarshal the JSON menu
 	menu, err := a.Repository.UnmarshalJsonMenu(data)
 	if err != nil {
@@ -20,6 +24,11 @@ func (a *Application) AddMenu(data []byte) (int, error) {
 		return http.StatusBadRequest, err
 	}
 
+	// Check that recipe units match the inventory units
+	if err = a.checkRecipeUnits(menu); err != nil {
+		return http.StatusBadRequest, err
+	}
+
 	// Get all menu items
 	allData, err := a.Repository.GetMenuItems()
 	if err != nil {
@@ -32,6 +41,11 @@ func (a *Application) AddMenu(data []byte) (int, error) {
 		return http.StatusInternalServerError, err
 	}
 
+	// Check that bundle components are on the menu
+	if err = checkBundleComponents(menu, menuItems); err != nil {
+		return http.StatusBadRequest, err
+	}
+
 	// Check if the menu item already exists
 	for _, item := range menuItems {
 		if item.ID == menu.ID {
@@ -56,20 +70,27 @@ func (a *Application) AddMenu(data []byte) (int, error) {
 	if err != nil {
 		return http.StatusInternalServerError, err
 	}
+	a.indexMenuItem(menu)
 
 	return http.StatusOK, nil
 }
 
-func (a *Application) GetAllMenuItems() ([]byte, int, error) {
-	data, err := a.Repository.GetMenuItems()
+// GetAllMenuItems возвращает страницу позиций меню, подходящих под фильтр
+func (a *Application) GetAllMenuItems(filter domain.MenuFilter, options domain.ListOptions) ([]byte, domain.PageInfo, int, error) {
+	menuItems, err := a.getMenuItems()
 	if err != nil {
-		return nil, http.StatusNoContent, err
+		return nil, domain.PageInfo{}, http.StatusInternalServerError, err
 	}
 
-	if len(data) <= 2 {
-		return nil, http.StatusNotFound, fmt.Errorf("no menu items found")
+	page, info, err := listItems(menuItems, filter.Matches, menuSorts, options)
+	if err != nil {
+		return nil, domain.PageInfo{}, http.StatusBadRequest, err
+	}
+	data, err := a.Repository.MarshalJsonMenuItems(page)
+	if err != nil {
+		return nil, domain.PageInfo{}, http.StatusInternalServerError, err
 	}
-	return data, http.StatusOK, nil
+	return data, info, http.StatusOK, nil
 }
 
 func (a *Application) GetMenuItemByID(id string) ([]byte, int, error) {
@@ -101,6 +122,9 @@ func (a *Application) GetMenuItemByID(id string) ([]byte, int, error) {
 }
 
 func (a *Application) UpdateMenuItemByID(id string, data []byte) (int, error) {
+	a.Repository.Lock()
+	defer a.Repository.Unlock()
+
 	// Get the menu item by ID
 	menu, err := a.Repository.UnmarshalJsonMenu(data)
 	if err != nil {
@@ -112,6 +136,11 @@ func (a *Application) UpdateMenuItemByID(id string, data []byte) (int, error) {
 		return http.StatusBadRequest, err
 	}
 
+	// Check that recipe units match the inventory units
+	if err = a.checkRecipeUnits(menu); err != nil {
+		return http.StatusBadRequest, err
+	}
+
 	// Get all menu items
 	allData, err := a.Repository.GetMenuItems()
 	if err != nil {
@@ -124,10 +153,17 @@ func (a *Application) UpdateMenuItemByID(id string, data []byte) (int, error) {
 		return http.StatusInternalServerError, err
 	}
 
+	// Check that bundle components are on the menu
+	if err = checkBundleComponents(menu, menuItems); err != nil {
+		return http.StatusBadRequest, err
+	}
+
 	// Update the menu item
+	updated := false
 	for i, item := range menuItems {
 		if item.ID == id {
 			menuItems[i] = menu
+			updated = true
 			break
 		}
 	}
@@ -143,11 +179,18 @@ func (a *Application) UpdateMenuItemByID(id string, data []byte) (int, error) {
 	if err != nil {
 		return http.StatusInternalServerError, err
 	}
+	if updated {
+		a.searchIndex.Remove(search.KindMenu, id)
+		a.indexMenuItem(menu)
+	}
 
 	return http.StatusOK, nil
 }
 
 func (a *Application) DeleteMenuItemByID(id string) (int, error) {
+	a.Repository.Lock()
+	defer a.Repository.Unlock()
+
 	// Get all menu items
 	allData, err := a.Repository.GetMenuItems()
 	if err != nil {
@@ -164,6 +207,11 @@ func (a *Application) DeleteMenuItemByID(id string) (int, error) {
 		return http.StatusInternalServerError, err
 	}
 
+	// Menu items sold in bundles can't be removed
+	if bundle := findBundleWith(id, menuItems); bundle != nil {
+		return http.StatusConflict, fmt.Errorf("menu item %s is a component of bundle %s", id, bundle.ID)
+	}
+
 	// Find the menu item by ID
 	for i, item := range menuItems {
 		if item.ID == id {
@@ -184,6 +232,7 @@ func (a *Application) DeleteMenuItemByID(id string) (int, error) {
 	if err != nil {
 		return http.StatusInternalServerError, err
 	}
+	a.searchIndex.Remove(search.KindMenu, id)
 
 	return http.StatusNoContent, nil
 }
@@ -210,7 +259,63 @@ func CheckMenuItemFields(menuItem *domain.MenuItem) error {
 		if ingredient.Quantity <= 0 {
 			return fmt.Errorf("ingredient %s in menu item %s must have a positive quantity", ingredient.IngredientID, menuItem.ID)
 		}
+
+		if ingredient.Unit != "" {
+			if _, ok := domain.LookupUnit(ingredient.Unit); !ok {
+				return fmt.Errorf("ingredient %s in menu item %s has unknown unit %q", ingredient.IngredientID, menuItem.ID, ingredient.Unit)
+			}
+		}
+	}
+
+	for _, component := range menuItem.Components {
+		if (component.ProductID == "") == (component.Category == "") {
+			return fmt.Errorf("components of bundle %s need either a product ID or a category", menuItem.ID)
+		}
+
+		if component.Quantity <= 0 {
+			return fmt.Errorf("components of bundle %s must have a positive quantity", menuItem.ID)
+		}
 	}
 
 	return nil
 }
+
+// checkRecipeUnits проверяет, что единицы измерения в рецепте совместимы с единицами инвентаря
+func (a *Application) checkRecipeUnits(menuItem *domain.MenuItem) error {
+	inventoryData, err := a.Repository.GetInventoryItems()
+	if err != nil {
+		return err
+	}
+
+	inventoryItems, err := a.Repository.UnmarshalInventoryItems(inventoryData)
+	if err != nil {
+		return err
+	}
+	return recipeUnitsError(menuItem, inventoryItems)
+}
+
+// recipeUnitsError сверяет единицы измерения рецепта с единицами переданных ингредиентов
+func recipeUnitsError(menuItem *domain.MenuItem, inventoryItems []*domain.InventoryItem) error {
+	for _, ingredient := range menuItem.Ingredients {
+		if ingredient.Unit == "" {
+			continue
+		}
+		inventoryItem := findInventoryItem(ingredient.IngredientID, inventoryItems)
+		if inventoryItem == nil {
+			continue
+		}
+		if err := domain.CompatibleUnits(ingredient.Unit, inventoryItem.Unit); err != nil {
+			return fmt.Errorf("ingredient %s in menu item %s: %w", ingredient.IngredientID, menuItem.ID, err)
+		}
+	}
+	return nil
+}
+
+// getMenuItems читает и десериализует все позиции меню
+func (a *Application) getMenuItems() ([]*domain.MenuItem, error) {
+	data, err := a.Repository.GetMenuItems()
+	if err != nil {
+		return nil, err
+	}
+	return a.Repository.UnmarshalJsonMenuItems(data)
+}
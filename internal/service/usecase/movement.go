@@ -0,0 +1,223 @@
+package usecase
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"time"
+
+	"hot-coffee/internal/domain"
+)
+
+// Допустимая погрешность при сравнении остатков с журналом
+const driftEpsilon = 1e-9
+
+func newMovement(ingredientID string, movementType domain.MovementType, delta float64, reason, orderID, user string) *domain.StockMovement {
+	return &domain.StockMovement{
+		ID:           generateID("MOV"),
+		IngredientID: ingredientID,
+		Type:         movementType,
+		Delta:        delta,
+		Reason:       reason,
+		OrderID:      orderID,
+		User:         user,
+		CreatedAt:    time.Now(),
+	}
+}
+
+// getMovements читает весь журнал движений
+func (a *Application) getMovements() ([]*domain.StockMovement, error) {
+	data, err := a.Repository.GetMovements()
+	if err != nil {
+		return nil, err
+	}
+	return a.Repository.UnmarshalJsonMovements(data)
+}
+
+// recordMovements обновляет партии, рассчитывает стоимость движений и дописывает их в журнал
+func (a *Application) recordMovements(movements ...*domain.StockMovement) error {
+	if len(movements) == 0 {
+		return nil
+	}
+
+	ledger, err := a.getMovements()
+	if err != nil {
+		return err
+	}
+
+	if err := a.applyLots(movements, ledgerValues(ledger, time.Time{})); err != nil {
+		return err
+	}
+
+	ledger = append(ledger, movements...)
+
+	data, err := a.Repository.MarshalJsonMovements(ledger)
+	if err != nil {
+		return err
+	}
+	return a.Repository.SaveMovements(data)
+}
+
+// GetInventoryMovements возвращает движения по ингредиенту за период [from, to).
+// Нулевые границы периода не ограничивают выборку.
+func (a *Application) GetInventoryMovements(id string, from, to time.Time) ([]byte, int, error) {
+	inventoryData, err := a.Repository.GetInventoryItems()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	inventoryItems, err := a.Repository.UnmarshalInventoryItems(inventoryData)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	ledger, err := a.getMovements()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	found := findInventoryItem(id, inventoryItems) != nil
+	movements := make([]*domain.StockMovement, 0)
+	for _, movement := range ledger {
+		if movement.IngredientID != id {
+			continue
+		}
+		found = true
+		if inPeriod(movement.CreatedAt, from, to) {
+			movements = append(movements, movement)
+		}
+	}
+
+	if !found {
+		return nil, http.StatusNotFound, fmt.Errorf("inventory item with Ingredient ID %s not found", id)
+	}
+
+	data, err := a.Repository.MarshalJsonMovements(movements)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	return data, http.StatusOK, nil
+}
+
+// GetStockDrift сравнивает текущие остатки с остатками, рассчитанными по журналу
+func (a *Application) GetStockDrift() ([]byte, int, error) {
+	_, drifts, err := a.calculateStockDrift()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	data, err := json.Marshal(drifts)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	return data, http.StatusOK, nil
+}
+
+// RebuildInventoryFromLedger пересчитывает остатки по журналу движений и сохраняет их.
+// Возвращает найденные расхождения, которые были исправлены.
+func (a *Application) RebuildInventoryFromLedger() ([]byte, int, error) {
+	a.Repository.Lock()
+	defer a.unlockAndNotify()
+
+	inventoryItems, drifts, err := a.calculateStockDrift()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	for _, drift := range drifts {
+		if item := findInventoryItem(drift.IngredientID, inventoryItems); item != nil {
+			item.Quantity = drift.LedgerQuantity
+		}
+	}
+
+	if len(drifts) > 0 {
+		inventoryData, err := a.Repository.MarshalInventoryItems(inventoryItems)
+		if err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+		if err := a.Repository.SaveInventoryItems(inventoryData); err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+		a.evaluateStockLevels(inventoryItems)
+	}
+
+	data, err := json.Marshal(drifts)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	return data, http.StatusOK, nil
+}
+
+// calculateStockDrift находит ингредиенты, остаток которых не совпадает с суммой движений
+func (a *Application) calculateStockDrift() ([]*domain.InventoryItem, []domain.StockDrift, error) {
+	inventoryData, err := a.Repository.GetInventoryItems()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	inventoryItems, err := a.Repository.UnmarshalInventoryItems(inventoryData)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ledger, err := a.getMovements()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	balances := make(map[string]float64)
+	for _, movement := range ledger {
+		balances[movement.IngredientID] += movement.Delta
+	}
+
+	drifts := make([]domain.StockDrift, 0)
+	for _, item := range inventoryItems {
+		ledgerQuantity := balances[item.IngredientID]
+		if math.Abs(item.Quantity-ledgerQuantity) <= driftEpsilon {
+			continue
+		}
+		drifts = append(drifts, domain.StockDrift{
+			IngredientID:   item.IngredientID,
+			Name:           item.Name,
+			ActualQuantity: item.Quantity,
+			LedgerQuantity: ledgerQuantity,
+			Drift:          item.Quantity - ledgerQuantity,
+		})
+	}
+	return inventoryItems, drifts, nil
+}
+
+// InitLedger записывает начальные остатки для ингредиентов, у которых еще нет движений в журнале
+func (a *Application) InitLedger() error {
+	a.Repository.Lock()
+	defer a.Repository.Unlock()
+
+	inventoryData, err := a.Repository.GetInventoryItems()
+	if err != nil {
+		return err
+	}
+
+	inventoryItems, err := a.Repository.UnmarshalInventoryItems(inventoryData)
+	if err != nil {
+		return err
+	}
+
+	ledger, err := a.getMovements()
+	if err != nil {
+		return err
+	}
+
+	tracked := make(map[string]bool)
+	for _, movement := range ledger {
+		tracked[movement.IngredientID] = true
+	}
+
+	var openings []*domain.StockMovement
+	for _, item := range inventoryItems {
+		if !tracked[item.IngredientID] && item.Quantity != 0 {
+			openings = append(openings, newMovement(item.IngredientID, domain.MovementAdjustment, item.Quantity, "opening balance", "", ""))
+		}
+	}
+	return a.recordMovements(openings...)
+}
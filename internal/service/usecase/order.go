@@ -1,17 +1,23 @@
 package usecase
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math/rand"
 	"net/http"
+	"slices"
 	"strings"
 	"time"
 
 	"hot-coffee/internal/domain"
+	"hot-coffee/internal/search"
 )
 
 func (a *Application) AddOrder(data []byte) (int, error) {
+	a.Repository.Lock()
+	defer a.Repository.Unlock()
+
 	order, err := a.Repository.UnmarshalJsonOrderItem(data)
 	if err != nil {
 		return http.StatusBadRequest, fmt.Errorf("invalid order data")
@@ -20,10 +26,18 @@ func (a *Application) AddOrder(data []byte) (int, error) {
 	order.ID = generateOrderID()
 	order.Status = domain.StatusPending
 	order.CreatedAt = time.Now()
+	if status, err := a.linkOrderCustomer(order); err != nil {
+		return status, err
+	}
 	if err := CheckOrderFields(order); err != nil {
 		return http.StatusBadRequest, err
 	}
 
+	// Orders can't be added to a closed business day
+	if status, err := a.checkDayOpen(order.CreatedAt); err != nil {
+		return status, err
+	}
+
 	// Get menu items
 	menuData, err := a.Repository.GetMenuItems()
 	if err != nil {
@@ -45,20 +59,38 @@ func (a *Application) AddOrder(data []byte) (int, error) {
 	if err != nil {
 		return http.StatusInternalServerError, fmt.Errorf("error unmarshalling inventory items")
 	}
+	// Expired lots wait for the write-off and can't be sold
+	expired, err := a.expiredStock(time.Now())
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("error getting inventory lots")
+	}
 
 	for _, item := range order.Items {
 		menuItem := findMenuItem(item.ProductID, menuItems)
 		if menuItem == nil {
 			return http.StatusBadRequest, fmt.Errorf("menu item %s not found", item.ProductID)
 		}
-		if !hasIngredient(menuItem.Ingredients, inventoryItems) {
+		recipe, err := orderItemRecipe(item, menuItem, menuItems)
+		if err != nil {
+			return http.StatusBadRequest, err
+		}
+		if !hasIngredient(recipe, inventoryItems) {
 			return http.StatusConflict, fmt.Errorf("ingredient for menu item %s not found in inventory", menuItem.ID)
 		}
-		if !checkIngredientsAvailability(item.Quantity, menuItem.Ingredients, inventoryItems) {
+		if !checkIngredientsAvailability(item.Quantity, recipe, inventoryItems, expired) {
 			return http.StatusConflict, fmt.Errorf("insufficient ingredients for menu item %s", menuItem.ID)
 		}
 	}
 
+	// Apply promotions and the coupon, then pay for the loyalty reward with the customer's points
+	if status, err := a.priceOrder(order, menuItems); err != nil {
+		return status, err
+	}
+	loyaltyEntries, status, err := a.redeemReward(order, nil, menuItems)
+	if err != nil {
+		return status, err
+	}
+
 	// Save the order
 	allData, err := a.Repository.GetOrders()
 	if err != nil {
@@ -81,23 +113,30 @@ func (a *Application) AddOrder(data []byte) (int, error) {
 	if err != nil {
 		return http.StatusInternalServerError, fmt.Errorf("error saving orders")
 	}
+	if err := a.recordLoyalty(loyaltyEntries...); err != nil {
+		return http.StatusInternalServerError, err
+	}
+	a.indexOrder(order)
 
 	return http.StatusCreated, nil
 }
 
-func (a *Application) GetAllOrders() ([]byte, int, error) {
-	// Get all orders
-	allData, err := a.Repository.GetOrders()
+// GetAllOrders возвращает страницу заказов, подходящих под фильтр
+func (a *Application) GetAllOrders(filter domain.OrderFilter, options domain.ListOptions) ([]byte, domain.PageInfo, int, error) {
+	orders, err := a.getOrders()
 	if err != nil {
-		return nil, http.StatusInternalServerError, err
+		return nil, domain.PageInfo{}, http.StatusInternalServerError, err
 	}
 
-	// Check if there are no orders
-	if len(allData) <= 2 {
-		return nil, http.StatusNotFound, fmt.Errorf("no orders found")
+	page, info, err := listItems(orders, filter.Matches, orderSorts, options)
+	if err != nil {
+		return nil, domain.PageInfo{}, http.StatusBadRequest, err
 	}
-
-	return allData, http.StatusOK, nil
+	data, err := a.Repository.MarshalJsonOrders(page)
+	if err != nil {
+		return nil, domain.PageInfo{}, http.StatusInternalServerError, err
+	}
+	return data, info, http.StatusOK, nil
 }
 
 func (a *Application) GetOrderByID(id string) ([]byte, int, error) {
@@ -128,6 +167,9 @@ func (a *Application) GetOrderByID(id string) ([]byte, int, error) {
 }
 
 func (a *Application) UpdateOrderByID(id string, data []byte) (int, error) {
+	a.Repository.Lock()
+	defer a.Repository.Unlock()
+
 	// Unmarshal the JSON order
 	newOrder, err := a.Repository.UnmarshalJsonOrderItem(data)
 	if err != nil {
@@ -137,11 +179,20 @@ func (a *Application) UpdateOrderByID(id string, data []byte) (int, error) {
 	newOrder.ID = id
 	newOrder.Status = domain.StatusPending
 	newOrder.CreatedAt = time.Now()
+	// Resolve the linked customer before the name check
+	if status, err := a.linkOrderCustomer(newOrder); err != nil {
+		return status, err
+	}
 	// Check if all fields are set
 	if err := CheckOrderFields(newOrder); err != nil {
 		return http.StatusBadRequest, err
 	}
 
+	// Orders can't be moved into a closed business day
+	if status, err := a.checkDayOpen(newOrder.CreatedAt); err != nil {
+		return status, err
+	}
+
 	// Get menu items
 	menuData, err := a.Repository.GetMenuItems()
 	if err != nil {
@@ -163,6 +214,11 @@ func (a *Application) UpdateOrderByID(id string, data []byte) (int, error) {
 	if err != nil {
 		return http.StatusInternalServerError, fmt.Errorf("error unmarshalling inventory items")
 	}
+	// Expired lots wait for the write-off and can't be sold
+	expired, err := a.expiredStock(time.Now())
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("error getting inventory lots")
+	}
 
 	for _, item := range newOrder.Items {
 		menuItem := findMenuItem(item.ProductID, menuItems)
@@ -170,15 +226,25 @@ func (a *Application) UpdateOrderByID(id string, data []byte) (int, error) {
 			return http.StatusBadRequest, fmt.Errorf("menu item %s not found", item.ProductID)
 		}
 
-		if !hasIngredient(menuItem.Ingredients, inventoryItems) {
+		recipe, err := orderItemRecipe(item, menuItem, menuItems)
+		if err != nil {
+			return http.StatusBadRequest, err
+		}
+
+		if !hasIngredient(recipe, inventoryItems) {
 			return http.StatusConflict, fmt.Errorf("ingredient for menu item %s not found in inventory", menuItem.ID)
 		}
 
-		if !checkIngredientsAvailability(item.Quantity, menuItem.Ingredients, inventoryItems) {
+		if !checkIngredientsAvailability(item.Quantity, recipe, inventoryItems, expired) {
 			return http.StatusConflict, fmt.Errorf("insufficient ingredients for menu item %s", menuItem.ID)
 		}
 	}
 
+	// Re-price the order with the promotions active now
+	if status, err := a.priceOrder(newOrder, menuItems); err != nil {
+		return status, err
+	}
+
 	// Get all orders
 	allData, err := a.Repository.GetOrders()
 	if err != nil {
@@ -192,16 +258,39 @@ func (a *Application) UpdateOrderByID(id string, data []byte) (int, error) {
 	}
 
 	// Update the order
+	updated := false
+	var previous *domain.Order
 	for i, item := range orders {
 		if item.ID == id {
 			if item.Status != domain.StatusPending {
-				return http.StatusConflict, fmt.Errorf("order %s is already completed", id)
+				return http.StatusConflict, fmt.Errorf("order %s is already %s", id, item.Status)
+			}
+			if status, err := a.checkDayOpen(item.CreatedAt); err != nil {
+				return status, err
 			}
+			previous = item
+			newOrder.Payments = item.Payments
 			orders[i] = newOrder
+			updated = true
 			break
 		}
 	}
 
+	// Return the points of the previous reward and pay for the new one
+	var loyaltyEntries []*domain.LoyaltyEntry
+	if updated {
+		var status int
+		loyaltyEntries, status, err = a.redeemReward(newOrder, previous, menuItems)
+		if err != nil {
+			return status, err
+		}
+
+		// Payments already taken must still fit into the new total
+		if summary := orderPayments(newOrder, menuItems); summary.Balance < -moneyEpsilon {
+			return http.StatusConflict, fmt.Errorf("order %s is already paid %.2f, more than the new total of %.2f", id, summary.Paid, summary.Total)
+		}
+	}
+
 	// Marshal the JSON orders
 	ordersJson, err := a.Repository.MarshalJsonOrders(orders)
 	if err != nil {
@@ -213,11 +302,20 @@ func (a *Application) UpdateOrderByID(id string, data []byte) (int, error) {
 	if err != nil {
 		return http.StatusInternalServerError, err
 	}
+	if err := a.recordLoyalty(loyaltyEntries...); err != nil {
+		return http.StatusInternalServerError, err
+	}
+	if updated {
+		a.indexOrder(newOrder)
+	}
 
 	return http.StatusOK, nil
 }
 
 func (a *Application) DeleteOrderByID(id string) (int, error) {
+	a.Repository.Lock()
+	defer a.Repository.Unlock()
+
 	// Get all orders
 	allData, err := a.Repository.GetOrders()
 	if err != nil {
@@ -231,8 +329,17 @@ func (a *Application) DeleteOrderByID(id string) (int, error) {
 	}
 
 	// Delete the order
+	var deleted *domain.Order
 	for i, item := range orders {
 		if item.ID == id {
+			if status, err := a.checkDayOpen(item.CreatedAt); err != nil {
+				return status, err
+			}
+			// Paid orders keep their payment records
+			if len(item.Payments) > 0 {
+				return http.StatusConflict, fmt.Errorf("order %s has payments", id)
+			}
+			deleted = item
 			orders = append(orders[:i], orders[i+1:]...)
 			break
 		}
@@ -249,11 +356,21 @@ func (a *Application) DeleteOrderByID(id string) (int, error) {
 	if err != nil {
 		return http.StatusInternalServerError, err
 	}
+	// Points earned or redeemed by a deleted order go back
+	if deleted != nil {
+		if err := a.reverseOrderPoints(deleted, "order deleted"); err != nil {
+			return http.StatusInternalServerError, err
+		}
+	}
+	a.searchIndex.Remove(search.KindOrder, id)
 
 	return http.StatusNoContent, nil
 }
 
-func (a *Application) CloseOrderByID(id string) (int, error) {
+func (a *Application) CloseOrderByID(id, user string) (int, error) {
+	a.Repository.Lock()
+	defer a.unlockAndNotify()
+
 	// Get all orders
 	allData, err := a.Repository.GetOrders()
 	if err != nil {
@@ -270,7 +387,9 @@ func (a *Application) CloseOrderByID(id string) (int, error) {
 	var targetOrder *domain.Order
 	for i, order := range orders {
 		if order.ID == id && order.Status == domain.StatusPending {
+			completedAt := time.Now()
 			orders[i].Status = domain.StatusCompleted
+			orders[i].CompletedAt = &completedAt
 			targetOrder = orders[i]
 			break
 		}
@@ -280,6 +399,9 @@ func (a *Application) CloseOrderByID(id string) (int, error) {
 	if targetOrder == nil {
 		return http.StatusNotFound, fmt.Errorf("order %s not found or already completed", id)
 	}
+	if status, err := a.checkDayOpen(targetOrder.CreatedAt); err != nil {
+		return status, err
+	}
 
 	// Get menu items
 	menuData, err := a.Repository.GetMenuItems()
@@ -291,6 +413,11 @@ func (a *Application) CloseOrderByID(id string) (int, error) {
 		return http.StatusInternalServerError, err
 	}
 
+	// Only fully paid orders can be closed
+	if balance := orderPayments(targetOrder, menuItems).Balance; balance > moneyEpsilon {
+		return http.StatusConflict, fmt.Errorf("order %s has an outstanding balance of %.2f", id, balance)
+	}
+
 	// Get inventory items
 	inventoryData, err := a.Repository.GetInventoryItems()
 	if err != nil {
@@ -300,19 +427,34 @@ func (a *Application) CloseOrderByID(id string) (int, error) {
 	if err != nil {
 		return http.StatusInternalServerError, err
 	}
+	expired, err := a.expiredStock(time.Now())
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
 
 	// Update inventory
+	movements := make([]*domain.StockMovement, 0)
 	for _, orderItem := range targetOrder.Items {
 		menuItem := findMenuItem(orderItem.ProductID, menuItems)
 		if menuItem == nil {
 			return http.StatusBadRequest, fmt.Errorf("menu item %s not found", orderItem.ProductID)
 		}
 
+		// Bundles decrement the recipes of their components
+		recipe, err := orderItemRecipe(orderItem, menuItem, menuItems)
+		if err != nil {
+			return http.StatusBadRequest, err
+		}
+
 		// decrement inventory
-		for _, ingredient := range menuItem.Ingredients {
-			if err := decrementInventory(ingredient, orderItem.Quantity, inventoryItems); err != nil {
-				return http.StatusConflict, fmt.Errorf("insufficient ingredients for menu item %s", menuItem.ID)
+		for _, ingredient := range recipe {
+			amount, err := decrementInventory(ingredient, orderItem.Quantity, inventoryItems, expired)
+			if err != nil {
+				return http.StatusConflict, fmt.Errorf("insufficient ingredients for menu item %s: %w", menuItem.ID, err)
 			}
+			movement := newMovement(ingredient.IngredientID, domain.MovementSale, -amount, "order closed", targetOrder.ID, user)
+			movement.ProductID = menuItem.ID
+			movements = append(movements, movement)
 		}
 	}
 
@@ -334,9 +476,165 @@ func (a *Application) CloseOrderByID(id string) (int, error) {
 		return http.StatusInternalServerError, err
 	}
 
+	// Record the sale in the stock ledger
+	if err := a.recordMovements(movements...); err != nil {
+		return http.StatusInternalServerError, err
+	}
+	if err := a.earnPoints(targetOrder, menuItems, user); err != nil {
+		return http.StatusInternalServerError, err
+	}
+	a.evaluateStockLevels(inventoryItems)
+
+	return http.StatusOK, nil
+}
+
+// CancelOrderByID отменяет незавершенный заказ. Отмененный заказ не списывает ингредиенты и учитывается в отчете о закрытии дня;
+// баллы, списанные за награду, возвращаются клиенту. Оплаченный заказ отменить нельзя, пока оплаты не отменены.
+func (a *Application) CancelOrderByID(id string) (int, error) {
+	a.Repository.Lock()
+	defer a.Repository.Unlock()
+
+	orders, err := a.getOrders()
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	order := findOrder(id, orders)
+	if order == nil {
+		return http.StatusNotFound, fmt.Errorf("order with ID %s not found", id)
+	}
+	if order.Status != domain.StatusPending {
+		return http.StatusConflict, fmt.Errorf("order %s is already %s", id, order.Status)
+	}
+	if status, err := a.checkDayOpen(order.CreatedAt); err != nil {
+		return status, err
+	}
+	if len(order.Payments) > 0 {
+		return http.StatusConflict, fmt.Errorf("order %s has payments; void them before cancelling", id)
+	}
+	order.Status = domain.StatusCancelled
+
+	ordersJson, err := a.Repository.MarshalJsonOrders(orders)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	if err := a.Repository.SaveOrders(ordersJson); err != nil {
+		return http.StatusInternalServerError, err
+	}
+	if err := a.reverseOrderPoints(order, "order cancelled"); err != nil {
+		return http.StatusInternalServerError, err
+	}
+	a.indexOrder(order)
+
 	return http.StatusOK, nil
 }
 
+// RefundOrderByID возвращает деньги за завершенный заказ: каждая оплата отменяется встречной оплатой тем же способом,
+// начисленные и списанные за заказ баллы клиента отменяются. Ингредиенты возвращаются на склад только при restock —
+// по умолчанию приготовленный заказ считается израсходованным. Возврат проводится в текущем открытом дне.
+func (a *Application) RefundOrderByID(id string, data []byte, user string) ([]byte, int, error) {
+	var refund domain.OrderRefund
+	if err := json.Unmarshal(data, &refund); err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("invalid refund data")
+	}
+	refund.Reason = strings.TrimSpace(refund.Reason)
+	if refund.Reason == "" {
+		return nil, http.StatusBadRequest, errors.New("refund reason is required")
+	}
+
+	a.Repository.Lock()
+	defer a.unlockAndNotify()
+
+	orders, err := a.getOrders()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	order := findOrder(id, orders)
+	if order == nil {
+		return nil, http.StatusNotFound, fmt.Errorf("order with ID %s not found", id)
+	}
+	if order.Status != domain.StatusCompleted {
+		return nil, http.StatusConflict, fmt.Errorf("order %s is %s; only completed orders can be refunded", id, order.Status)
+	}
+	refund.User = user
+	refund.RefundedAt = time.Now()
+	if status, err := a.checkDayOpen(refund.RefundedAt); err != nil {
+		return nil, status, err
+	}
+
+	// Reverse every payment with the same method
+	for _, payment := range slices.Clone(order.Payments) {
+		order.Payments = append(order.Payments, domain.Payment{
+			ID:        generateID("PAY"),
+			Method:    payment.Method,
+			Amount:    -payment.Amount,
+			Tip:       -payment.Tip,
+			Reference: payment.Reference,
+			RefundOf:  payment.ID,
+			User:      user,
+			CreatedAt: refund.RefundedAt,
+		})
+	}
+	order.Status = domain.StatusRefunded
+	order.Refund = &refund
+
+	// Return the ingredients written off when the order was closed, at their original cost
+	movements := make([]*domain.StockMovement, 0)
+	var inventoryItems []*domain.InventoryItem
+	if refund.Restock {
+		ledger, err := a.getMovements()
+		if err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+		inventoryItems, err = a.getInventoryItems()
+		if err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+		for _, sale := range ledger {
+			if sale.OrderID != id || sale.Type != domain.MovementSale || sale.Delta >= 0 {
+				continue
+			}
+			inventoryItem := findInventoryItem(sale.IngredientID, inventoryItems)
+			if inventoryItem == nil {
+				continue
+			}
+			inventoryItem.Quantity -= sale.Delta
+			movement := newMovement(sale.IngredientID, domain.MovementReturn, -sale.Delta, "order refunded", id, user)
+			movement.ProductID = sale.ProductID
+			movement.UnitCost = sale.UnitCost
+			movement.Cost = -sale.Cost
+			movements = append(movements, movement)
+		}
+		if err := a.saveInventoryItems(inventoryItems); err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+	}
+
+	ordersJson, err := a.Repository.MarshalJsonOrders(orders)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if err := a.Repository.SaveOrders(ordersJson); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if err := a.recordMovements(movements...); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if err := a.reverseOrderPoints(order, "order refunded"); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if refund.Restock {
+		a.evaluateStockLevels(inventoryItems)
+	}
+	a.indexOrder(order)
+
+	orderJson, err := a.Repository.MarshalJsonOrderItem(order)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	return orderJson, http.StatusOK, nil
+}
+
 // Additional functions
 func findMenuItem(id string, menuItems []*domain.MenuItem) *domain.MenuItem {
 	for _, item := range menuItems {
@@ -347,12 +645,34 @@ func findMenuItem(id string, menuItems []*domain.MenuItem) *domain.MenuItem {
 	return nil
 }
 
-func checkIngredientsAvailability(quantity int, ingredients []domain.MenuItemIngredient, inventoryItems []*domain.InventoryItem) bool {
+func findInventoryItem(id string, inventoryItems []*domain.InventoryItem) *domain.InventoryItem {
+	for _, item := range inventoryItems {
+		if item.IngredientID == id {
+			return item
+		}
+	}
+	return nil
+}
+
+// requiredQuantity переводит расход ингредиента на заказ в единицы измерения инвентаря
+func requiredQuantity(ingredient domain.MenuItemIngredient, orderQuantity int, inventoryItem *domain.InventoryItem) (float64, error) {
+	required := ingredient.Quantity * float64(orderQuantity)
+	if ingredient.Unit == "" {
+		return required, nil
+	}
+	return domain.ConvertUnit(required, ingredient.Unit, inventoryItem.Unit)
+}
+
+// checkIngredientsAvailability проверяет остаток ингредиентов без учета просроченных партий expired
+func checkIngredientsAvailability(quantity int, ingredients []domain.MenuItemIngredient, inventoryItems []*domain.InventoryItem, expired map[string]float64) bool {
 	for _, ingredient := range ingredients {
-		for _, inventoryItem := range inventoryItems {
-			if ingredient.IngredientID == inventoryItem.IngredientID && inventoryItem.Quantity < float64(quantity) {
-				return false
-			}
+		inventoryItem := findInventoryItem(ingredient.IngredientID, inventoryItems)
+		if inventoryItem == nil {
+			return false
+		}
+		required, err := requiredQuantity(ingredient, quantity, inventoryItem)
+		if err != nil || inventoryItem.Quantity-expired[ingredient.IngredientID] < required {
+			return false
 		}
 	}
 	return true
@@ -360,33 +680,39 @@ func checkIngredientsAvailability(quantity int, ingredients []domain.MenuItemIng
 
 func hasIngredient(menuItemIngredients []domain.MenuItemIngredient, inventoryItems []*domain.InventoryItem) bool {
 	for _, ingredient := range menuItemIngredients {
-		for _, inventoryItem := range inventoryItems {
-			if inventoryItem.IngredientID == ingredient.IngredientID {
-				return true
-			}
+		if findInventoryItem(ingredient.IngredientID, inventoryItems) == nil {
+			return false
 		}
 	}
-	return false
+	return true
 }
 
-func decrementInventory(ingredient domain.MenuItemIngredient, orderQuantity int, inventoryItems []*domain.InventoryItem) error {
-	requiredQuantity := ingredient.Quantity * float64(orderQuantity)
-	for _, inventoryItem := range inventoryItems {
-		if inventoryItem.IngredientID == ingredient.IngredientID {
-			if inventoryItem.Quantity < requiredQuantity {
-				return fmt.Errorf("insufficient quantity for ingredient %s", ingredient.IngredientID)
-			}
-			inventoryItem.Quantity -= requiredQuantity
-			return nil
-		}
+// decrementInventory списывает ингредиент и возвращает списанное количество в единицах инвентаря.
+// Количество в просроченных партиях expired для списания недоступно.
+func decrementInventory(ingredient domain.MenuItemIngredient, orderQuantity int, inventoryItems []*domain.InventoryItem, expired map[string]float64) (float64, error) {
+	inventoryItem := findInventoryItem(ingredient.IngredientID, inventoryItems)
+	if inventoryItem == nil {
+		return 0, fmt.Errorf("ingredient %s not found in inventory", ingredient.IngredientID)
 	}
-	return fmt.Errorf("ingredient %s not found in inventory", ingredient.IngredientID)
+	required, err := requiredQuantity(ingredient, orderQuantity, inventoryItem)
+	if err != nil {
+		return 0, fmt.Errorf("ingredient %s: %w", ingredient.IngredientID, err)
+	}
+	if inventoryItem.Quantity-expired[ingredient.IngredientID] < required {
+		return 0, fmt.Errorf("insufficient quantity for ingredient %s", ingredient.IngredientID)
+	}
+	inventoryItem.Quantity -= required
+	return required, nil
 }
 
 func generateOrderID() string {
+	return generateID("ORD")
+}
+
+func generateID(prefix string) string {
 	timestamp := time.Now().UnixNano()
 	randomNumber := rand.Intn(10000)
-	return strings.ReplaceAll(fmt.Sprintf("ORD-%d-%04d", timestamp, randomNumber), "/", "")
+	return strings.ReplaceAll(fmt.Sprintf("%s-%d-%04d", prefix, timestamp, randomNumber), "/", "")
 }
 
 func CheckOrderFields(order *domain.Order) error {
@@ -417,3 +743,12 @@ func CheckOrderFields(order *domain.Order) error {
 
 	return nil
 }
+
+// getOrders читает и десериализует все заказы
+func (a *Application) getOrders() ([]*domain.Order, error) {
+	data, err := a.Repository.GetOrders()
+	if err != nil {
+		return nil, err
+	}
+	return a.Repository.UnmarshalJsonOrders(data)
+}
@@ -0,0 +1,232 @@
+package usecase
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"slices"
+	"time"
+
+	"hot-coffee/internal/domain"
+)
+
+// Допустимая погрешность при сравнении денежных сумм
+const moneyEpsilon = 0.005
+
+// AddPayment добавляет оплату к незавершенному заказу. Заказ можно оплатить несколькими способами;
+// для наличных без суммы оплаты в счет заказа идет полученная сумма за вычетом чаевых, остальное — сдача.
+func (a *Application) AddPayment(orderID string, data []byte, user string) ([]byte, int, error) {
+	var payment domain.Payment
+	if err := json.Unmarshal(data, &payment); err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("invalid payment data")
+	}
+	if !payment.Method.IsValid() {
+		return nil, http.StatusBadRequest, fmt.Errorf("invalid payment method: %s", payment.Method)
+	}
+	if payment.Amount < 0 || payment.Tip < 0 || payment.Tendered < 0 {
+		return nil, http.StatusBadRequest, errors.New("payment amounts must not be negative")
+	}
+	if payment.Method != domain.PaymentCash && payment.Tendered > 0 {
+		return nil, http.StatusBadRequest, errors.New("tendered amount applies only to cash payments")
+	}
+
+	a.Repository.Lock()
+	defer a.Repository.Unlock()
+
+	orders, err := a.getOrders()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	order := findOrder(orderID, orders)
+	if order == nil {
+		return nil, http.StatusNotFound, fmt.Errorf("order with ID %s not found", orderID)
+	}
+	if order.Status != domain.StatusPending {
+		return nil, http.StatusConflict, fmt.Errorf("order %s is already %s", orderID, order.Status)
+	}
+	if status, err := a.checkDayOpen(order.CreatedAt); err != nil {
+		return nil, status, err
+	}
+
+	menuItems, err := a.getMenuItems()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	balance := orderPayments(order, menuItems).Balance
+	if balance < moneyEpsilon {
+		return nil, http.StatusConflict, fmt.Errorf("order %s is already paid", orderID)
+	}
+
+	if payment.Method == domain.PaymentCash && payment.Amount == 0 {
+		payment.Amount = math.Min(payment.Tendered-payment.Tip, balance)
+	}
+	payment.Amount = roundMoney(payment.Amount)
+	payment.Tip = roundMoney(payment.Tip)
+	if payment.Amount <= 0 {
+		return nil, http.StatusBadRequest, errors.New("payment amount must be greater than zero")
+	}
+	if payment.Amount > balance+moneyEpsilon {
+		return nil, http.StatusBadRequest, fmt.Errorf("payment of %.2f exceeds the balance of %.2f", payment.Amount, balance)
+	}
+
+	// Сдача — все, что получено сверх оплаты и чаевых
+	if payment.Method == domain.PaymentCash {
+		if payment.Tendered == 0 {
+			payment.Tendered = payment.Amount + payment.Tip
+		}
+		payment.Tendered = roundMoney(payment.Tendered)
+		if payment.Tendered < payment.Amount+payment.Tip-moneyEpsilon {
+			return nil, http.StatusBadRequest, fmt.Errorf("tendered %.2f doesn't cover the amount and tip of %.2f", payment.Tendered, payment.Amount+payment.Tip)
+		}
+		payment.Change = roundMoney(payment.Tendered - payment.Amount - payment.Tip)
+	}
+
+	payment.ID = generateID("PAY")
+	payment.User = user
+	payment.CreatedAt = time.Now()
+	order.Payments = append(order.Payments, payment)
+
+	ordersJson, err := a.Repository.MarshalJsonOrders(orders)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if err := a.Repository.SaveOrders(ordersJson); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	data, err = json.Marshal(orderPayments(order, menuItems))
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	return data, http.StatusCreated, nil
+}
+
+// GetOrderPayments возвращает оплаты заказа и остаток к оплате
+func (a *Application) GetOrderPayments(orderID string) ([]byte, int, error) {
+	orders, err := a.getOrders()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	order := findOrder(orderID, orders)
+	if order == nil {
+		return nil, http.StatusNotFound, fmt.Errorf("order with ID %s not found", orderID)
+	}
+
+	menuItems, err := a.getMenuItems()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	data, err := json.Marshal(orderPayments(order, menuItems))
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	return data, http.StatusOK, nil
+}
+
+// VoidPayment отменяет оплату незавершенного заказа
+func (a *Application) VoidPayment(orderID, paymentID string) (int, error) {
+	a.Repository.Lock()
+	defer a.Repository.Unlock()
+
+	orders, err := a.getOrders()
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	order := findOrder(orderID, orders)
+	if order == nil {
+		return http.StatusNotFound, fmt.Errorf("order with ID %s not found", orderID)
+	}
+	index := slices.IndexFunc(order.Payments, func(payment domain.Payment) bool { return payment.ID == paymentID })
+	if index == -1 {
+		return http.StatusNotFound, fmt.Errorf("payment %s not found in order %s", paymentID, orderID)
+	}
+	if order.Status != domain.StatusPending {
+		return http.StatusConflict, fmt.Errorf("order %s is already %s", orderID, order.Status)
+	}
+	if status, err := a.checkDayOpen(order.CreatedAt); err != nil {
+		return status, err
+	}
+	order.Payments = slices.Delete(order.Payments, index, index+1)
+
+	ordersJson, err := a.Repository.MarshalJsonOrders(orders)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	if err := a.Repository.SaveOrders(ordersJson); err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	return http.StatusNoContent, nil
+}
+
+// orderPayments считает сумму заказа к оплате по зафиксированным в нем ценам, оплаченную часть и остаток.
+// Цены меню используются только для старых заказов без зафиксированных цен.
+func orderPayments(order *domain.Order, menuItems []*domain.MenuItem) domain.OrderPayments {
+	summary := domain.OrderPayments{
+		OrderID:  order.ID,
+		Subtotal: roundMoney(orderSubtotal(order, menuItems)),
+		Discount: roundMoney(order.Discount()),
+		Paid:     roundMoney(order.Paid()),
+		Payments: make([]domain.Payment, 0, len(order.Payments)),
+	}
+	summary.Total = roundMoney(math.Max(summary.Subtotal-summary.Discount, 0))
+	summary.Balance = roundMoney(summary.Total - summary.Paid)
+	for _, payment := range order.Payments {
+		summary.Tips += payment.Tip
+		summary.Payments = append(summary.Payments, payment)
+	}
+	summary.Tips = roundMoney(summary.Tips)
+	return summary
+}
+
+// paymentBreakdown суммирует оплаты проданных заказов по способам оплаты и чаевые.
+// Неоплаченная часть заказов, закрытых до учета оплат, относится к способу paymentUnspecified.
+// Встречные оплаты возвратов не учитываются: они относятся ко дню возврата (см. refundBreakdown).
+func paymentBreakdown(orders []*domain.Order, menuItems []*domain.MenuItem) (map[string]float64, float64) {
+	byMethod := make(map[string]float64)
+	var tips float64
+	for _, order := range orders {
+		var paid float64
+		for _, payment := range order.Payments {
+			if payment.RefundOf != "" {
+				continue
+			}
+			byMethod[string(payment.Method)] += payment.Amount
+			tips += payment.Tip
+			paid += payment.Amount
+		}
+		if balance := orderPayments(order, menuItems).Total - paid; balance > moneyEpsilon {
+			byMethod[paymentUnspecified] += balance
+		}
+	}
+	for method, amount := range byMethod {
+		byMethod[method] = roundMoney(amount)
+	}
+	return byMethod, roundMoney(tips)
+}
+
+// refundBreakdown добавляет в byMethod встречные оплаты возвращенных заказов и возвращает чаевые с учетом возвратов.
+// Неоплаченная часть заказов, закрытых до учета оплат, возвращается по способу paymentUnspecified.
+func refundBreakdown(orders []*domain.Order, menuItems []*domain.MenuItem, byMethod map[string]float64, tips float64) float64 {
+	for _, order := range orders {
+		var refunded float64
+		for _, payment := range order.Payments {
+			if payment.RefundOf == "" {
+				continue
+			}
+			byMethod[string(payment.Method)] += payment.Amount
+			tips += payment.Tip
+			refunded -= payment.Amount
+		}
+		if balance := orderPayments(order, menuItems).Total - refunded; balance > moneyEpsilon {
+			byMethod[paymentUnspecified] -= balance
+		}
+	}
+	for method, amount := range byMethod {
+		byMethod[method] = roundMoney(amount)
+	}
+	return roundMoney(tips)
+}
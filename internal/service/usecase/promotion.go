@@ -0,0 +1,413 @@
+package usecase
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"hot-coffee/internal/domain"
+)
+
+// Порядок применения акций: сначала бесплатные позиции, затем проценты, затем фиксированные суммы
+var promotionOrder = map[domain.PromotionType]int{
+	domain.PromotionBuyXGetY:   0,
+	domain.PromotionPercentage: 1,
+	domain.PromotionFixed:      2,
+}
+
+func (a *Application) AddPromotion(data []byte) (int, error) {
+	promotion, err := a.Repository.UnmarshalJsonPromotion(data)
+	if err != nil {
+		return http.StatusBadRequest, fmt.Errorf("invalid promotion data")
+	}
+
+	a.Repository.Lock()
+	defer a.Repository.Unlock()
+
+	if err := a.validatePromotion(promotion); err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	promotions, err := a.getPromotions()
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	if findPromotion(promotion.ID, promotions) != nil {
+		return http.StatusConflict, fmt.Errorf("promotion with ID %s already exists", promotion.ID)
+	}
+	if err := checkCouponCode(promotion, promotions); err != nil {
+		return http.StatusConflict, err
+	}
+
+	promotions = append(promotions, promotion)
+	if err := a.savePromotions(promotions); err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	return http.StatusCreated, nil
+}
+
+func (a *Application) GetAllPromotions() ([]byte, int, error) {
+	promotions, err := a.getPromotions()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	data, err := a.Repository.MarshalJsonPromotions(promotions)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	return data, http.StatusOK, nil
+}
+
+func (a *Application) GetPromotionByID(id string) ([]byte, int, error) {
+	promotions, err := a.getPromotions()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	promotion := findPromotion(id, promotions)
+	if promotion == nil {
+		return nil, http.StatusNotFound, fmt.Errorf("promotion with ID %s not found", id)
+	}
+
+	data, err := a.Repository.MarshalJsonPromotion(promotion)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	return data, http.StatusOK, nil
+}
+
+// UpdatePromotionByID изменяет акцию. Уже оформленные заказы сохраняют рассчитанные скидки.
+func (a *Application) UpdatePromotionByID(id string, data []byte) (int, error) {
+	promotion, err := a.Repository.UnmarshalJsonPromotion(data)
+	if err != nil {
+		return http.StatusBadRequest, fmt.Errorf("invalid promotion data")
+	}
+	promotion.ID = id
+
+	a.Repository.Lock()
+	defer a.Repository.Unlock()
+
+	if err := a.validatePromotion(promotion); err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	promotions, err := a.getPromotions()
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	existing := findPromotion(id, promotions)
+	if existing == nil {
+		return http.StatusNotFound, fmt.Errorf("promotion with ID %s not found", id)
+	}
+	if err := checkCouponCode(promotion, promotions); err != nil {
+		return http.StatusConflict, err
+	}
+	*existing = *promotion
+
+	if err := a.savePromotions(promotions); err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	return http.StatusOK, nil
+}
+
+func (a *Application) DeletePromotionByID(id string) (int, error) {
+	a.Repository.Lock()
+	defer a.Repository.Unlock()
+
+	promotions, err := a.getPromotions()
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	i := slices.IndexFunc(promotions, func(promotion *domain.Promotion) bool { return promotion.ID == id })
+	if i < 0 {
+		return http.StatusNotFound, fmt.Errorf("promotion with ID %s not found", id)
+	}
+	promotions = slices.Delete(promotions, i, i+1)
+
+	if err := a.savePromotions(promotions); err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	return http.StatusNoContent, nil
+}
+
+// priceOrder фиксирует в заказе текущие цены меню и применяет действующие на момент создания акции и купон заказа.
+// Скидки акций складываются, но не превышают стоимость позиций, на которые действуют.
+// Купон, который не найден или не подходит к заказу, отклоняет заказ.
+func (a *Application) priceOrder(order *domain.Order, menuItems []*domain.MenuItem) (int, error) {
+	order.Promotions = nil
+	order.CouponCode = strings.TrimSpace(order.CouponCode)
+	for i := range order.Items {
+		order.Items[i].Price = nil
+		if menuItem := findMenuItem(order.Items[i].ProductID, menuItems); menuItem != nil {
+			price := menuItem.Price
+			order.Items[i].Price = &price
+		}
+	}
+
+	promotions, err := a.getPromotions()
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	var coupon *domain.Promotion
+	if order.CouponCode != "" {
+		coupon = findCoupon(order.CouponCode, promotions)
+		if coupon == nil {
+			return http.StatusBadRequest, fmt.Errorf("coupon %s not found", order.CouponCode)
+		}
+		if !coupon.ActiveAt(order.CreatedAt) {
+			return http.StatusBadRequest, fmt.Errorf("coupon %s is not valid at this time", order.CouponCode)
+		}
+	}
+
+	orders, err := a.getOrders()
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	// Стоимость позиций заказа, оставшаяся после уже примененных акций
+	lines := make([]*domain.MenuItem, len(order.Items))
+	remaining := make([]float64, len(order.Items))
+	for i, item := range order.Items {
+		lines[i] = findMenuItem(item.ProductID, menuItems)
+		if lines[i] != nil {
+			remaining[i] = float64(item.Quantity) * *item.Price
+		}
+	}
+
+	candidates := slices.Clone(promotions)
+	slices.SortStableFunc(candidates, func(x, y *domain.Promotion) int {
+		return cmp.Or(cmp.Compare(promotionOrder[x.Type], promotionOrder[y.Type]), cmp.Compare(x.ID, y.ID))
+	})
+	for _, promotion := range candidates {
+		if promotion.CouponCode != "" && promotion != coupon {
+			continue
+		}
+		if !promotion.ActiveAt(order.CreatedAt) {
+			continue
+		}
+		if promotion.UsageLimit > 0 && promotionUses(promotion.ID, order.ID, orders) >= promotion.UsageLimit {
+			if promotion == coupon {
+				return http.StatusConflict, fmt.Errorf("coupon %s has reached its usage limit", order.CouponCode)
+			}
+			continue
+		}
+
+		discount := applyPromotion(promotion, order, lines, remaining)
+		if discount <= 0 {
+			if promotion == coupon {
+				return http.StatusBadRequest, fmt.Errorf("coupon %s does not apply to the order items", order.CouponCode)
+			}
+			continue
+		}
+		order.Promotions = append(order.Promotions, domain.AppliedPromotion{
+			PromotionID: promotion.ID,
+			Name:        promotion.Name,
+			CouponCode:  promotion.CouponCode,
+			Discount:    roundMoney(discount),
+		})
+	}
+	return http.StatusOK, nil
+}
+
+// applyPromotion считает скидку акции и уменьшает на нее оставшуюся стоимость позиций
+func applyPromotion(promotion *domain.Promotion, order *domain.Order, lines []*domain.MenuItem, remaining []float64) float64 {
+	eligible := make([]int, 0, len(lines))
+	var eligibleTotal float64
+	for i, menuItem := range lines {
+		if menuItem != nil && remaining[i] > 0 && promotion.Covers(menuItem) {
+			eligible = append(eligible, i)
+			eligibleTotal += remaining[i]
+		}
+	}
+	if len(eligible) == 0 {
+		return 0
+	}
+
+	var discount float64
+	switch promotion.Type {
+	case domain.PromotionPercentage:
+		for _, i := range eligible {
+			lineDiscount := remaining[i] * promotion.Value / 100
+			remaining[i] -= lineDiscount
+			discount += lineDiscount
+		}
+	case domain.PromotionFixed:
+		discount = min(promotion.Value, eligibleTotal)
+		// Распределяем скидку пропорционально стоимости позиций
+		for _, i := range eligible {
+			remaining[i] -= remaining[i] / eligibleTotal * discount
+		}
+	case domain.PromotionBuyXGetY:
+		units := 0
+		for _, i := range eligible {
+			units += order.Items[i].Quantity
+		}
+		free := units / (promotion.BuyQuantity + promotion.GetQuantity) * promotion.GetQuantity
+
+		// Бесплатными становятся самые дешевые единицы
+		slices.SortStableFunc(eligible, func(x, y int) int {
+			return cmp.Compare(remaining[x]/float64(order.Items[x].Quantity), remaining[y]/float64(order.Items[y].Quantity))
+		})
+		for _, i := range eligible {
+			if free == 0 {
+				break
+			}
+			quantity := min(free, order.Items[i].Quantity)
+			lineDiscount := remaining[i] / float64(order.Items[i].Quantity) * float64(quantity)
+			remaining[i] -= lineDiscount
+			discount += lineDiscount
+			free -= quantity
+		}
+	}
+	return discount
+}
+
+// promotionUses считает неотмененные заказы с акцией, кроме заказа exceptOrderID
+func promotionUses(promotionID, exceptOrderID string, orders []*domain.Order) int {
+	uses := 0
+	for _, order := range orders {
+		if order.ID == exceptOrderID || order.Status == domain.StatusCancelled {
+			continue
+		}
+		if slices.ContainsFunc(order.Promotions, func(applied domain.AppliedPromotion) bool { return applied.PromotionID == promotionID }) {
+			uses++
+		}
+	}
+	return uses
+}
+
+// promotionUsage подводит итоги акций по завершенным заказам
+func promotionUsage(orders []*domain.Order) []domain.PromotionUsage {
+	byPromotion := make(map[string]*domain.PromotionUsage)
+	for _, order := range orders {
+		for _, applied := range order.Promotions {
+			usage, ok := byPromotion[applied.PromotionID]
+			if !ok {
+				usage = &domain.PromotionUsage{PromotionID: applied.PromotionID, Name: applied.Name}
+				byPromotion[applied.PromotionID] = usage
+			}
+			usage.Orders++
+			usage.Discount += applied.Discount
+		}
+	}
+
+	usages := make([]domain.PromotionUsage, 0, len(byPromotion))
+	for _, usage := range byPromotion {
+		usage.Discount = roundMoney(usage.Discount)
+		usages = append(usages, *usage)
+	}
+	slices.SortFunc(usages, func(x, y domain.PromotionUsage) int {
+		return cmp.Or(cmp.Compare(y.Discount, x.Discount), cmp.Compare(x.PromotionID, y.PromotionID))
+	})
+	return usages
+}
+
+func (a *Application) getPromotions() ([]*domain.Promotion, error) {
+	data, err := a.Repository.GetPromotions()
+	if err != nil {
+		return nil, err
+	}
+	return a.Repository.UnmarshalJsonPromotions(data)
+}
+
+func (a *Application) savePromotions(promotions []*domain.Promotion) error {
+	data, err := a.Repository.MarshalJsonPromotions(promotions)
+	if err != nil {
+		return err
+	}
+	return a.Repository.SavePromotions(data)
+}
+
+func findPromotion(id string, promotions []*domain.Promotion) *domain.Promotion {
+	for _, promotion := range promotions {
+		if promotion.ID == id {
+			return promotion
+		}
+	}
+	return nil
+}
+
+// findCoupon ищет акцию по коду купона без учета регистра
+func findCoupon(code string, promotions []*domain.Promotion) *domain.Promotion {
+	for _, promotion := range promotions {
+		if promotion.CouponCode != "" && strings.EqualFold(promotion.CouponCode, code) {
+			return promotion
+		}
+	}
+	return nil
+}
+
+func checkCouponCode(promotion *domain.Promotion, promotions []*domain.Promotion) error {
+	if promotion.CouponCode == "" {
+		return nil
+	}
+	if other := findCoupon(promotion.CouponCode, promotions); other != nil && other.ID != promotion.ID {
+		return fmt.Errorf("coupon %s already belongs to promotion %s", promotion.CouponCode, other.ID)
+	}
+	return nil
+}
+
+// validatePromotion проверяет акцию и наличие ее позиций в меню
+func (a *Application) validatePromotion(promotion *domain.Promotion) error {
+	promotion.CouponCode = strings.TrimSpace(promotion.CouponCode)
+	if promotion.ID == "" {
+		return errors.New("promotion ID is required")
+	}
+	if promotion.Name == "" {
+		return errors.New("promotion name is required")
+	}
+
+	switch promotion.Type {
+	case domain.PromotionPercentage:
+		if promotion.Value <= 0 || promotion.Value > 100 {
+			return errors.New("percentage must be in the range (0, 100]")
+		}
+	case domain.PromotionFixed:
+		if promotion.Value <= 0 {
+			return errors.New("discount amount must be greater than zero")
+		}
+	case domain.PromotionBuyXGetY:
+		if promotion.BuyQuantity < 1 || promotion.GetQuantity < 1 {
+			return errors.New("buy and get quantities must be at least 1")
+		}
+	default:
+		return fmt.Errorf("invalid promotion type: %q", promotion.Type)
+	}
+
+	for _, day := range promotion.Weekdays {
+		if !slices.ContainsFunc(trafficWeekdays, func(weekday time.Weekday) bool { return strings.EqualFold(weekday.String(), day) }) {
+			return fmt.Errorf("invalid weekday: %q", day)
+		}
+	}
+	if promotion.StartHour < 0 || promotion.StartHour > 23 || promotion.EndHour < 0 || promotion.EndHour > 24 {
+		return errors.New("hours must be in the range [0, 24]")
+	}
+	if promotion.StartsAt != nil && promotion.EndsAt != nil && !promotion.StartsAt.Before(*promotion.EndsAt) {
+		return errors.New("starts_at must be before ends_at")
+	}
+	if promotion.UsageLimit < 0 {
+		return errors.New("usage limit must not be negative")
+	}
+
+	menuItems, err := a.getMenuItems()
+	if err != nil {
+		return err
+	}
+	for _, productID := range promotion.ProductIDs {
+		if findMenuItem(productID, menuItems) == nil {
+			return fmt.Errorf("menu item %s not found", productID)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,448 @@
+package usecase
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"time"
+
+	"hot-coffee/internal/domain"
+)
+
+// CreatePurchaseOrder создает черновик заказа поставщику.
+// Размер и цена упаковки берутся из каталога поставщика.
+func (a *Application) CreatePurchaseOrder(data []byte) ([]byte, int, error) {
+	var request domain.PurchaseOrder
+	if err := json.Unmarshal(data, &request); err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("invalid purchase order data")
+	}
+
+	if request.SupplierID == "" {
+		return nil, http.StatusBadRequest, errors.New("supplier ID is required")
+	}
+	if len(request.Lines) == 0 {
+		return nil, http.StatusBadRequest, errors.New("purchase order must contain at least one line")
+	}
+
+	a.Repository.Lock()
+	defer a.Repository.Unlock()
+
+	suppliers, err := a.getSuppliers()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	supplier := findSupplier(request.SupplierID, suppliers)
+	if supplier == nil {
+		return nil, http.StatusNotFound, fmt.Errorf("supplier with ID %s not found", request.SupplierID)
+	}
+
+	purchaseOrder := newPurchaseOrder(supplier.ID)
+	seen := make(map[string]bool, len(request.Lines))
+	for _, line := range request.Lines {
+		if seen[line.IngredientID] {
+			return nil, http.StatusBadRequest, fmt.Errorf("ingredient %s is listed more than once", line.IngredientID)
+		}
+		seen[line.IngredientID] = true
+		if line.Packs <= 0 {
+			return nil, http.StatusBadRequest, fmt.Errorf("packs for ingredient %s must be greater than zero", line.IngredientID)
+		}
+		item := findSupplierItem(line.IngredientID, supplier)
+		if item == nil {
+			return nil, http.StatusBadRequest, fmt.Errorf("supplier %s does not supply ingredient %s", supplier.ID, line.IngredientID)
+		}
+		addPurchaseOrderLine(purchaseOrder, item, line.Packs)
+	}
+
+	purchaseOrders, err := a.getPurchaseOrders()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	purchaseOrders = append(purchaseOrders, purchaseOrder)
+	if err := a.savePurchaseOrders(purchaseOrders); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	result, err := a.Repository.MarshalJsonPurchaseOrder(purchaseOrder)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	return result, http.StatusCreated, nil
+}
+
+// GeneratePurchaseOrders создает черновики заказов для ингредиентов с низким остатком.
+// Количество рассчитывается до нормативного запаса с учетом уже заказанного и округляется до целых упаковок;
+// для каждого ингредиента выбирается поставщик с самой низкой ценой за единицу.
+func (a *Application) GeneratePurchaseOrders() ([]byte, int, error) {
+	a.Repository.Lock()
+	defer a.Repository.Unlock()
+
+	inventoryItems, err := a.getInventoryItems()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	suppliers, err := a.getSuppliers()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	purchaseOrders, err := a.getPurchaseOrders()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	// Количество, которое уже заказано, но еще не получено
+	onOrder := outstandingQuantities(purchaseOrders, inventoryItems)
+
+	now := time.Now()
+	drafts := make(map[string]*domain.PurchaseOrder)
+	created := make([]*domain.PurchaseOrder, 0)
+	for _, inventoryItem := range inventoryItems {
+		if !inventoryItem.IsLowStock() {
+			continue
+		}
+		needed := domain.NewLowStockItem(inventoryItem, now).SuggestedQuantity - onOrder[inventoryItem.IngredientID]
+		if needed <= 0 {
+			continue
+		}
+
+		supplier, item, packSize := cheapestSupplier(inventoryItem, suppliers)
+		if supplier == nil {
+			a.logger().Printf("No supplier for low-stock ingredient %s", inventoryItem.IngredientID)
+			continue
+		}
+
+		purchaseOrder, ok := drafts[supplier.ID]
+		if !ok {
+			purchaseOrder = newPurchaseOrder(supplier.ID)
+			drafts[supplier.ID] = purchaseOrder
+			created = append(created, purchaseOrder)
+		}
+		addPurchaseOrderLine(purchaseOrder, item, int(math.Ceil(needed/packSize)))
+	}
+
+	if len(created) > 0 {
+		purchaseOrders = append(purchaseOrders, created...)
+		if err := a.savePurchaseOrders(purchaseOrders); err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+	}
+
+	result, err := a.Repository.MarshalJsonPurchaseOrders(created)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	return result, http.StatusOK, nil
+}
+
+func (a *Application) GetAllPurchaseOrders() ([]byte, int, error) {
+	purchaseOrders, err := a.getPurchaseOrders()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	data, err := a.Repository.MarshalJsonPurchaseOrders(purchaseOrders)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	return data, http.StatusOK, nil
+}
+
+func (a *Application) GetPurchaseOrderByID(id string) ([]byte, int, error) {
+	purchaseOrders, err := a.getPurchaseOrders()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	purchaseOrder := findPurchaseOrder(id, purchaseOrders)
+	if purchaseOrder == nil {
+		return nil, http.StatusNotFound, fmt.Errorf("purchase order with ID %s not found", id)
+	}
+
+	data, err := a.Repository.MarshalJsonPurchaseOrder(purchaseOrder)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	return data, http.StatusOK, nil
+}
+
+// SendPurchaseOrder отмечает черновик как отправленный поставщику
+func (a *Application) SendPurchaseOrder(id string) ([]byte, int, error) {
+	a.Repository.Lock()
+	defer a.Repository.Unlock()
+
+	purchaseOrders, err := a.getPurchaseOrders()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	purchaseOrder := findPurchaseOrder(id, purchaseOrders)
+	if purchaseOrder == nil {
+		return nil, http.StatusNotFound, fmt.Errorf("purchase order with ID %s not found", id)
+	}
+	if purchaseOrder.Status != domain.PurchaseOrderDraft {
+		return nil, http.StatusConflict, fmt.Errorf("purchase order %s is already %s", id, purchaseOrder.Status)
+	}
+
+	suppliers, err := a.getSuppliers()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	now := time.Now()
+	purchaseOrder.Status = domain.PurchaseOrderSent
+	purchaseOrder.SentAt = &now
+	if supplier := findSupplier(purchaseOrder.SupplierID, suppliers); supplier != nil {
+		expected := now.AddDate(0, 0, supplier.LeadTimeDays)
+		purchaseOrder.ExpectedAt = &expected
+	}
+
+	if err := a.savePurchaseOrders(purchaseOrders); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	data, err := a.Repository.MarshalJsonPurchaseOrder(purchaseOrder)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	return data, http.StatusOK, nil
+}
+
+// ReceivePurchaseOrder приходует товар по заказу поставщику.
+// Пустое тело запроса означает получение всего оставшегося количества.
+func (a *Application) ReceivePurchaseOrder(id string, data []byte, user string) ([]byte, int, error) {
+	var receipt domain.PurchaseOrderReceipt
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &receipt); err != nil {
+			return nil, http.StatusBadRequest, fmt.Errorf("invalid receipt data")
+		}
+	}
+
+	a.Repository.Lock()
+	defer a.unlockAndNotify()
+
+	purchaseOrders, err := a.getPurchaseOrders()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	purchaseOrder := findPurchaseOrder(id, purchaseOrders)
+	if purchaseOrder == nil {
+		return nil, http.StatusNotFound, fmt.Errorf("purchase order with ID %s not found", id)
+	}
+	if purchaseOrder.Status != domain.PurchaseOrderSent && purchaseOrder.Status != domain.PurchaseOrderPartiallyReceived {
+		return nil, http.StatusConflict, fmt.Errorf("purchase order %s is %s and cannot be received", id, purchaseOrder.Status)
+	}
+
+	// По умолчанию получаем все, что еще не получено
+	if len(receipt.Items) == 0 {
+		for _, line := range purchaseOrder.Lines {
+			if outstanding := line.Outstanding(); outstanding > 0 {
+				receipt.Items = append(receipt.Items, domain.StockReceiptItem{IngredientID: line.IngredientID, Quantity: outstanding})
+			}
+		}
+	}
+
+	inventoryItems, err := a.getInventoryItems()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	// Сначала проверяем все позиции, затем применяем
+	deltas := make([]float64, len(receipt.Items))
+	quantities := make([]float64, len(receipt.Items))
+	lines := make([]*domain.PurchaseOrderLine, len(receipt.Items))
+	seen := make(map[string]bool, len(receipt.Items))
+	for i, received := range receipt.Items {
+		if seen[received.IngredientID] {
+			return nil, http.StatusBadRequest, fmt.Errorf("ingredient %s is listed more than once", received.IngredientID)
+		}
+		seen[received.IngredientID] = true
+		line := findPurchaseOrderLine(received.IngredientID, purchaseOrder)
+		if line == nil {
+			return nil, http.StatusBadRequest, fmt.Errorf("ingredient %s is not on purchase order %s", received.IngredientID, id)
+		}
+		if received.Quantity <= 0 {
+			return nil, http.StatusBadRequest, fmt.Errorf("quantity for ingredient %s must be greater than zero", received.IngredientID)
+		}
+		if received.UnitCost < 0 {
+			return nil, http.StatusBadRequest, fmt.Errorf("unit cost for ingredient %s must not be negative", received.IngredientID)
+		}
+
+		inventoryItem := findInventoryItem(received.IngredientID, inventoryItems)
+		if inventoryItem == nil {
+			return nil, http.StatusNotFound, fmt.Errorf("inventory item with Ingredient ID %s not found", received.IngredientID)
+		}
+		// Поставка указывается в единицах строки заказа, если не задана своя единица
+		delta, err := toInventoryUnit(received.Quantity, cmp.Or(received.Unit, line.Unit), inventoryItem)
+		if err != nil {
+			return nil, http.StatusBadRequest, err
+		}
+		quantity := received.Quantity
+		if received.Unit != "" {
+			if quantity, err = domain.ConvertUnit(received.Quantity, received.Unit, cmp.Or(line.Unit, inventoryItem.Unit)); err != nil {
+				return nil, http.StatusBadRequest, fmt.Errorf("ingredient %s: %w", received.IngredientID, err)
+			}
+		}
+		if quantity > line.Outstanding()+driftEpsilon {
+			return nil, http.StatusBadRequest, fmt.Errorf("received quantity for ingredient %s exceeds outstanding %.2f", received.IngredientID, line.Outstanding())
+		}
+		deltas[i] = delta
+		quantities[i] = quantity
+		lines[i] = line
+	}
+
+	movements := make([]*domain.StockMovement, 0, len(receipt.Items))
+	for i, received := range receipt.Items {
+		inventoryItem := findInventoryItem(received.IngredientID, inventoryItems)
+		inventoryItem.Quantity += deltas[i]
+		lines[i].ReceivedQuantity += quantities[i]
+		movement := newMovement(received.IngredientID, domain.MovementRestock, deltas[i], "purchase order "+id, "", user)
+		movement.ExpiresAt = received.ExpiresAt
+		// Цена поставки задана за ее единицу, цена упаковки — за единицу строки заказа
+		if received.UnitCost > 0 {
+			movement.UnitCost = toInventoryUnitCost(received.UnitCost, received.Quantity, deltas[i])
+		} else if lines[i].PackSize > 0 {
+			movement.UnitCost = toInventoryUnitCost(lines[i].PackPrice/lines[i].PackSize, quantities[i], deltas[i])
+		}
+		movements = append(movements, movement)
+	}
+
+	purchaseOrder.Status = domain.PurchaseOrderReceived
+	for _, line := range purchaseOrder.Lines {
+		if line.Outstanding() > driftEpsilon {
+			purchaseOrder.Status = domain.PurchaseOrderPartiallyReceived
+			break
+		}
+	}
+	if purchaseOrder.Status == domain.PurchaseOrderReceived {
+		now := time.Now()
+		purchaseOrder.ReceivedAt = &now
+	}
+
+	if err := a.saveInventoryItems(inventoryItems); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if err := a.savePurchaseOrders(purchaseOrders); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if err := a.recordMovements(movements...); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	a.evaluateStockLevels(inventoryItems)
+
+	result, err := a.Repository.MarshalJsonPurchaseOrder(purchaseOrder)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	return result, http.StatusOK, nil
+}
+
+// outstandingQuantities возвращает заказанное, но еще не полученное количество ингредиентов в единицах инвентаря
+func outstandingQuantities(purchaseOrders []*domain.PurchaseOrder, inventoryItems []*domain.InventoryItem) map[string]float64 {
+	onOrder := make(map[string]float64)
+	for _, purchaseOrder := range purchaseOrders {
+		if purchaseOrder.Status == domain.PurchaseOrderReceived {
+			continue
+		}
+		for _, line := range purchaseOrder.Lines {
+			inventoryItem := findInventoryItem(line.IngredientID, inventoryItems)
+			if inventoryItem == nil {
+				continue
+			}
+			outstanding, err := toInventoryUnit(line.Outstanding(), line.Unit, inventoryItem)
+			if err != nil {
+				continue
+			}
+			onOrder[line.IngredientID] += outstanding
+		}
+	}
+	return onOrder
+}
+
+func newPurchaseOrder(supplierID string) *domain.PurchaseOrder {
+	return &domain.PurchaseOrder{
+		ID:         generateID("PO"),
+		SupplierID: supplierID,
+		Status:     domain.PurchaseOrderDraft,
+		Lines:      make([]domain.PurchaseOrderLine, 0),
+		CreatedAt:  time.Now(),
+	}
+}
+
+func addPurchaseOrderLine(purchaseOrder *domain.PurchaseOrder, item *domain.SupplierItem, packs int) {
+	purchaseOrder.Lines = append(purchaseOrder.Lines, domain.PurchaseOrderLine{
+		IngredientID: item.IngredientID,
+		Packs:        packs,
+		PackSize:     item.PackSize,
+		Unit:         item.Unit,
+		PackPrice:    item.PackPrice,
+		Quantity:     float64(packs) * item.PackSize,
+	})
+	purchaseOrder.Total += float64(packs) * item.PackPrice
+}
+
+// cheapestSupplier выбирает поставщика с минимальной ценой за единицу инвентаря.
+// Возвращает также размер упаковки в единицах инвентаря.
+func cheapestSupplier(inventoryItem *domain.InventoryItem, suppliers []*domain.Supplier) (*domain.Supplier, *domain.SupplierItem, float64) {
+	var (
+		bestSupplier *domain.Supplier
+		bestItem     *domain.SupplierItem
+		bestPackSize float64
+		bestPrice    float64
+	)
+	for _, supplier := range suppliers {
+		item := findSupplierItem(inventoryItem.IngredientID, supplier)
+		if item == nil {
+			continue
+		}
+		packSize, err := toInventoryUnit(item.PackSize, item.Unit, inventoryItem)
+		if err != nil || packSize <= 0 {
+			continue
+		}
+		unitPrice := item.PackPrice / packSize
+		if bestSupplier == nil || unitPrice < bestPrice {
+			bestSupplier, bestItem, bestPackSize, bestPrice = supplier, item, packSize, unitPrice
+		}
+	}
+	return bestSupplier, bestItem, bestPackSize
+}
+
+func (a *Application) getPurchaseOrders() ([]*domain.PurchaseOrder, error) {
+	data, err := a.Repository.GetPurchaseOrders()
+	if err != nil {
+		return nil, err
+	}
+	return a.Repository.UnmarshalJsonPurchaseOrders(data)
+}
+
+func (a *Application) savePurchaseOrders(purchaseOrders []*domain.PurchaseOrder) error {
+	data, err := a.Repository.MarshalJsonPurchaseOrders(purchaseOrders)
+	if err != nil {
+		return err
+	}
+	return a.Repository.SavePurchaseOrders(data)
+}
+
+func findPurchaseOrder(id string, purchaseOrders []*domain.PurchaseOrder) *domain.PurchaseOrder {
+	for _, purchaseOrder := range purchaseOrders {
+		if purchaseOrder.ID == id {
+			return purchaseOrder
+		}
+	}
+	return nil
+}
+
+func findPurchaseOrderLine(ingredientID string, purchaseOrder *domain.PurchaseOrder) *domain.PurchaseOrderLine {
+	for i := range purchaseOrder.Lines {
+		if purchaseOrder.Lines[i].IngredientID == ingredientID {
+			return &purchaseOrder.Lines[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package usecase
+
+import (
+	"time"
+
+	"hot-coffee/internal/domain"
+	"hot-coffee/internal/search"
+)
+
+// InitSearchIndex строит поисковый индекс по сохраненным меню, заказам и инвентарю
+func (a *Application) InitSearchIndex() error {
+	a.Repository.Lock()
+	defer a.Repository.Unlock()
+
+	menuItems, err := a.getMenuItems()
+	if err != nil {
+		return err
+	}
+	orders, err := a.getOrders()
+	if err != nil {
+		return err
+	}
+	inventoryItems, err := a.getInventoryItems()
+	if err != nil {
+		return err
+	}
+
+	for _, kind := range []string{search.KindMenu, search.KindOrder, search.KindInventory} {
+		a.searchIndex.Reset(kind)
+	}
+	for _, item := range menuItems {
+		a.indexMenuItem(item)
+	}
+	for _, order := range orders {
+		a.indexOrder(order)
+	}
+	for _, item := range inventoryItems {
+		a.indexInventoryItem(item)
+	}
+	return nil
+}
+
+func (a *Application) indexMenuItem(item *domain.MenuItem) {
+	a.searchIndex.Put(search.KindMenu, item.ID, item.Name, item.Description)
+}
+
+func (a *Application) indexOrder(order *domain.Order) {
+	a.searchIndex.Put(search.KindOrder, order.ID, order.CustomerName)
+}
+
+func (a *Application) indexInventoryItem(item *domain.InventoryItem) {
+	a.searchIndex.Put(search.KindInventory, item.IngredientID, item.Name)
+}
+
+// Search ищет query по названиям и описаниям меню, именам клиентов в заказах и названиям ингредиентов.
+// Заказы дополнительно ограничиваются периодом [from, to); limit ограничивает число результатов каждого типа.
+func (a *Application) Search(query string, from, to time.Time, limit int) (*domain.SearchResults, error) {
+	results := &domain.SearchResults{
+		Query:     query,
+		Menu:      make([]*domain.MenuItem, 0),
+		Orders:    make([]*domain.Order, 0),
+		Inventory: make([]*domain.InventoryItem, 0),
+	}
+
+	hits := a.searchIndex.Search(query)
+	if len(hits) == 0 {
+		return results, nil
+	}
+
+	menuItems, err := a.getMenuItems()
+	if err != nil {
+		return nil, err
+	}
+	orders, err := a.getOrders()
+	if err != nil {
+		return nil, err
+	}
+	inventoryItems, err := a.getInventoryItems()
+	if err != nil {
+		return nil, err
+	}
+
+	full := func(n int) bool { return limit > 0 && n >= limit }
+	for _, hit := range hits {
+		switch hit.Kind {
+		case search.KindMenu:
+			if item := findMenuItem(hit.ID, menuItems); item != nil && !full(len(results.Menu)) {
+				results.Menu = append(results.Menu, item)
+			}
+		case search.KindOrder:
+			if order := findOrder(hit.ID, orders); order != nil && inPeriod(order.CreatedAt, from, to) && !full(len(results.Orders)) {
+				results.Orders = append(results.Orders, order)
+			}
+		case search.KindInventory:
+			if item := findInventoryItem(hit.ID, inventoryItems); item != nil && !full(len(results.Inventory)) {
+				results.Inventory = append(results.Inventory, item)
+			}
+		}
+	}
+	results.Total = len(results.Menu) + len(results.Orders) + len(results.Inventory)
+	return results, nil
+}
+
+func findOrder(id string, orders []*domain.Order) *domain.Order {
+	for _, order := range orders {
+		if order.ID == id {
+			return order
+		}
+	}
+	return nil
+}
@@ -0,0 +1,377 @@
+package usecase
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"time"
+
+	"hot-coffee/internal/domain"
+)
+
+// OpenStockCount открывает новую сессию инвентаризации. Одновременно может быть открыта только одна сессия.
+func (a *Application) OpenStockCount(data []byte, user string) ([]byte, int, error) {
+	var request domain.StockCount
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &request); err != nil {
+			return nil, http.StatusBadRequest, fmt.Errorf("invalid stock count data")
+		}
+	}
+
+	a.Repository.Lock()
+	defer a.Repository.Unlock()
+
+	stockCounts, err := a.getStockCounts()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	for _, stockCount := range stockCounts {
+		if stockCount.Status == domain.StockCountOpen {
+			return nil, http.StatusConflict, fmt.Errorf("stock count %s is already open", stockCount.ID)
+		}
+	}
+
+	stockCount := &domain.StockCount{
+		ID:        generateID("CNT"),
+		Status:    domain.StockCountOpen,
+		Note:      request.Note,
+		OpenedBy:  user,
+		CreatedAt: time.Now(),
+		Lines:     make([]domain.StockCountLine, 0),
+	}
+
+	stockCounts = append(stockCounts, stockCount)
+	if err := a.saveStockCounts(stockCounts); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	result, err := json.Marshal(stockCount)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	return result, http.StatusCreated, nil
+}
+
+func (a *Application) GetAllStockCounts() ([]byte, int, error) {
+	stockCounts, err := a.getStockCounts()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	data, err := a.Repository.MarshalJsonStockCounts(stockCounts)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	return data, http.StatusOK, nil
+}
+
+func (a *Application) GetStockCountByID(id string) ([]byte, int, error) {
+	stockCounts, err := a.getStockCounts()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	stockCount := findStockCount(id, stockCounts)
+	if stockCount == nil {
+		return nil, http.StatusNotFound, fmt.Errorf("stock count with ID %s not found", id)
+	}
+
+	data, err := json.Marshal(stockCount)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	return data, http.StatusOK, nil
+}
+
+// SubmitStockCount записывает посчитанные количества. Повторный подсчет ингредиента заменяет предыдущий.
+func (a *Application) SubmitStockCount(id string, data []byte, user string) ([]byte, int, error) {
+	var submission domain.StockCountSubmission
+	if err := json.Unmarshal(data, &submission); err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("invalid stock count data")
+	}
+	if len(submission.Items) == 0 {
+		return nil, http.StatusBadRequest, errors.New("submission must contain at least one item")
+	}
+
+	a.Repository.Lock()
+	defer a.Repository.Unlock()
+
+	stockCounts, err := a.getStockCounts()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	stockCount := findStockCount(id, stockCounts)
+	if stockCount == nil {
+		return nil, http.StatusNotFound, fmt.Errorf("stock count with ID %s not found", id)
+	}
+	if stockCount.Status != domain.StockCountOpen {
+		return nil, http.StatusConflict, fmt.Errorf("stock count %s is already %s", id, stockCount.Status)
+	}
+
+	inventoryItems, err := a.getInventoryItems()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	// Сначала проверяем все позиции, затем записываем
+	lines := make([]domain.StockCountLine, 0, len(submission.Items))
+	now := time.Now()
+	for _, counted := range submission.Items {
+		item := findInventoryItem(counted.IngredientID, inventoryItems)
+		if item == nil {
+			return nil, http.StatusNotFound, fmt.Errorf("inventory item with Ingredient ID %s not found", counted.IngredientID)
+		}
+		if counted.Quantity < 0 {
+			return nil, http.StatusBadRequest, fmt.Errorf("counted quantity for ingredient %s must not be negative", counted.IngredientID)
+		}
+		quantity, err := toInventoryUnit(counted.Quantity, counted.Unit, item)
+		if err != nil {
+			return nil, http.StatusBadRequest, err
+		}
+		theoretical := item.Quantity
+		lines = append(lines, domain.StockCountLine{
+			IngredientID: item.IngredientID,
+			Quantity:     quantity,
+			Theoretical:  &theoretical,
+			Unit:         item.Unit,
+			CountedBy:    user,
+			CountedAt:    now,
+		})
+	}
+
+	for _, line := range lines {
+		replaced := false
+		for i := range stockCount.Lines {
+			if stockCount.Lines[i].IngredientID == line.IngredientID {
+				stockCount.Lines[i] = line
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			stockCount.Lines = append(stockCount.Lines, line)
+		}
+	}
+
+	if err := a.saveStockCounts(stockCounts); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	result, err := json.Marshal(stockCount)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	return result, http.StatusOK, nil
+}
+
+// GetStockCountVariance сравнивает посчитанные количества с учетными остатками
+func (a *Application) GetStockCountVariance(id string) ([]byte, int, error) {
+	stockCounts, err := a.getStockCounts()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	stockCount := findStockCount(id, stockCounts)
+	if stockCount == nil {
+		return nil, http.StatusNotFound, fmt.Errorf("stock count with ID %s not found", id)
+	}
+
+	inventoryItems, err := a.getInventoryItems()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	report, err := a.stockCountReport(stockCount, inventoryItems)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	data, err := json.Marshal(report)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	return data, http.StatusOK, nil
+}
+
+// CommitStockCount корректирует остатки на расхождения, выявленные при подсчете, и записывает корректировки в журнал.
+// Продажи, списания и поступления после подсчета ингредиента сохраняются.
+func (a *Application) CommitStockCount(id, user string) ([]byte, int, error) {
+	a.Repository.Lock()
+	defer a.unlockAndNotify()
+
+	stockCounts, err := a.getStockCounts()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	stockCount := findStockCount(id, stockCounts)
+	if stockCount == nil {
+		return nil, http.StatusNotFound, fmt.Errorf("stock count with ID %s not found", id)
+	}
+	if stockCount.Status != domain.StockCountOpen {
+		return nil, http.StatusConflict, fmt.Errorf("stock count %s is already %s", id, stockCount.Status)
+	}
+	if len(stockCount.Lines) == 0 {
+		return nil, http.StatusBadRequest, fmt.Errorf("stock count %s has no counted items", id)
+	}
+
+	inventoryItems, err := a.getInventoryItems()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	report, err := a.stockCountReport(stockCount, inventoryItems)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	movements := make([]*domain.StockMovement, 0)
+	for _, line := range report.Lines {
+		if math.Abs(line.Variance) <= driftEpsilon {
+			continue
+		}
+		item := findInventoryItem(line.IngredientID, inventoryItems)
+		item.Quantity += line.Variance
+		reason := fmt.Sprintf("stock count %s: counted %.3f %s, expected %.3f %s", id, line.Counted, line.Unit, line.Theoretical, line.Unit)
+		movements = append(movements, newMovement(line.IngredientID, domain.MovementAdjustment, line.Variance, reason, "", user))
+	}
+
+	now := time.Now()
+	stockCount.Status = domain.StockCountCommitted
+	stockCount.CommittedAt = &now
+	report.Status = stockCount.Status
+
+	if err := a.saveInventoryItems(inventoryItems); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if err := a.saveStockCounts(stockCounts); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if err := a.recordMovements(movements...); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	a.evaluateStockLevels(inventoryItems)
+
+	data, err := json.Marshal(report)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	return data, http.StatusOK, nil
+}
+
+// CancelStockCount отменяет открытую сессию инвентаризации без изменения остатков
+func (a *Application) CancelStockCount(id, user string) ([]byte, int, error) {
+	a.Repository.Lock()
+	defer a.Repository.Unlock()
+
+	stockCounts, err := a.getStockCounts()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	stockCount := findStockCount(id, stockCounts)
+	if stockCount == nil {
+		return nil, http.StatusNotFound, fmt.Errorf("stock count with ID %s not found", id)
+	}
+	if stockCount.Status != domain.StockCountOpen {
+		return nil, http.StatusConflict, fmt.Errorf("stock count %s is already %s", id, stockCount.Status)
+	}
+
+	now := time.Now()
+	stockCount.Status = domain.StockCountCancelled
+	stockCount.CancelledAt = &now
+	stockCount.CancelledBy = user
+
+	if err := a.saveStockCounts(stockCounts); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	data, err := json.Marshal(stockCount)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	return data, http.StatusOK, nil
+}
+
+// stockCountReport рассчитывает расхождения и их стоимость по сессии инвентаризации.
+// Расхождение считается от учетного остатка в момент подсчета, а для старых строк — от текущего.
+func (a *Application) stockCountReport(stockCount *domain.StockCount, inventoryItems []*domain.InventoryItem) (*domain.StockCountReport, error) {
+	unitCosts, err := a.ingredientUnitCosts(inventoryItems)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &domain.StockCountReport{
+		StockCountID: stockCount.ID,
+		Status:       stockCount.Status,
+		Lines:        make([]domain.StockVariance, 0, len(stockCount.Lines)),
+		Uncounted:    make([]string, 0),
+	}
+
+	counted := make(map[string]bool, len(stockCount.Lines))
+	for _, line := range stockCount.Lines {
+		item := findInventoryItem(line.IngredientID, inventoryItems)
+		if item == nil {
+			continue
+		}
+		counted[line.IngredientID] = true
+
+		theoretical := item.Quantity
+		if line.Theoretical != nil {
+			theoretical = *line.Theoretical
+		}
+		variance := domain.StockVariance{
+			IngredientID: item.IngredientID,
+			Name:         item.Name,
+			Unit:         item.Unit,
+			Theoretical:  theoretical,
+			Counted:      line.Quantity,
+			Variance:     line.Quantity - theoretical,
+			UnitCost:     unitCosts[item.IngredientID],
+		}
+		variance.CostImpact = variance.Variance * variance.UnitCost
+		report.TotalCostImpact += variance.CostImpact
+		report.Lines = append(report.Lines, variance)
+	}
+
+	for _, item := range inventoryItems {
+		if !counted[item.IngredientID] {
+			report.Uncounted = append(report.Uncounted, item.IngredientID)
+		}
+	}
+
+	sort.SliceStable(report.Lines, func(i, j int) bool {
+		return math.Abs(report.Lines[i].CostImpact) > math.Abs(report.Lines[j].CostImpact)
+	})
+	return report, nil
+}
+
+func (a *Application) getStockCounts() ([]*domain.StockCount, error) {
+	data, err := a.Repository.GetStockCounts()
+	if err != nil {
+		return nil, err
+	}
+	return a.Repository.UnmarshalJsonStockCounts(data)
+}
+
+func (a *Application) saveStockCounts(stockCounts []*domain.StockCount) error {
+	data, err := a.Repository.MarshalJsonStockCounts(stockCounts)
+	if err != nil {
+		return err
+	}
+	return a.Repository.SaveStockCounts(data)
+}
+
+func findStockCount(id string, stockCounts []*domain.StockCount) *domain.StockCount {
+	for _, stockCount := range stockCounts {
+		if stockCount.ID == id {
+			return stockCount
+		}
+	}
+	return nil
+}
@@ -0,0 +1,238 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"hot-coffee/internal/domain"
+)
+
+func (a *Application) AddSupplier(data []byte) (int, error) {
+	supplier, err := a.Repository.UnmarshalJsonSupplier(data)
+	if err != nil {
+		return http.StatusBadRequest, fmt.Errorf("invalid supplier data")
+	}
+
+	if err := validateSupplier(supplier); err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	a.Repository.Lock()
+	defer a.Repository.Unlock()
+
+	if err := a.checkSupplierItems(supplier); err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	suppliers, err := a.getSuppliers()
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	for _, item := range suppliers {
+		if item.ID == supplier.ID {
+			return http.StatusConflict, fmt.Errorf("supplier with ID %s already exists", supplier.ID)
+		}
+	}
+
+	suppliers = append(suppliers, supplier)
+	if err := a.saveSuppliers(suppliers); err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	return http.StatusCreated, nil
+}
+
+func (a *Application) GetAllSuppliers() ([]byte, int, error) {
+	suppliers, err := a.getSuppliers()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	data, err := a.Repository.MarshalJsonSuppliers(suppliers)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	return data, http.StatusOK, nil
+}
+
+func (a *Application) GetSupplierByID(id string) ([]byte, int, error) {
+	suppliers, err := a.getSuppliers()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	supplier := findSupplier(id, suppliers)
+	if supplier == nil {
+		return nil, http.StatusNotFound, fmt.Errorf("supplier with ID %s not found", id)
+	}
+
+	data, err := a.Repository.MarshalJsonSupplier(supplier)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	return data, http.StatusOK, nil
+}
+
+func (a *Application) UpdateSupplierByID(id string, data []byte) (int, error) {
+	supplier, err := a.Repository.UnmarshalJsonSupplier(data)
+	if err != nil {
+		return http.StatusBadRequest, fmt.Errorf("invalid supplier data")
+	}
+
+	supplier.ID = id
+	if err := validateSupplier(supplier); err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	a.Repository.Lock()
+	defer a.Repository.Unlock()
+
+	if err := a.checkSupplierItems(supplier); err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	suppliers, err := a.getSuppliers()
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	updated := false
+	for i, item := range suppliers {
+		if item.ID == id {
+			suppliers[i] = supplier
+			updated = true
+			break
+		}
+	}
+	if !updated {
+		return http.StatusNotFound, fmt.Errorf("supplier with ID %s not found", id)
+	}
+
+	if err := a.saveSuppliers(suppliers); err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	return http.StatusOK, nil
+}
+
+func (a *Application) DeleteSupplierByID(id string) (int, error) {
+	a.Repository.Lock()
+	defer a.Repository.Unlock()
+
+	suppliers, err := a.getSuppliers()
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	deleted := false
+	for i, item := range suppliers {
+		if item.ID == id {
+			suppliers = append(suppliers[:i], suppliers[i+1:]...)
+			deleted = true
+			break
+		}
+	}
+	if !deleted {
+		return http.StatusNotFound, fmt.Errorf("supplier with ID %s not found", id)
+	}
+
+	if err := a.saveSuppliers(suppliers); err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	return http.StatusNoContent, nil
+}
+
+// checkSupplierItems проверяет, что поставляемые ингредиенты есть в инвентаре
+// и единицы упаковки совместимы с единицами инвентаря
+func (a *Application) checkSupplierItems(supplier *domain.Supplier) error {
+	inventoryItems, err := a.getInventoryItems()
+	if err != nil {
+		return err
+	}
+
+	for _, item := range supplier.Items {
+		inventoryItem := findInventoryItem(item.IngredientID, inventoryItems)
+		if inventoryItem == nil {
+			return fmt.Errorf("ingredient %s not found in inventory", item.IngredientID)
+		}
+		if item.Unit == "" {
+			continue
+		}
+		if err := domain.CompatibleUnits(item.Unit, inventoryItem.Unit); err != nil {
+			return fmt.Errorf("ingredient %s: %w", item.IngredientID, err)
+		}
+	}
+	return nil
+}
+
+func (a *Application) getSuppliers() ([]*domain.Supplier, error) {
+	data, err := a.Repository.GetSuppliers()
+	if err != nil {
+		return nil, err
+	}
+	return a.Repository.UnmarshalJsonSuppliers(data)
+}
+
+func (a *Application) saveSuppliers(suppliers []*domain.Supplier) error {
+	data, err := a.Repository.MarshalJsonSuppliers(suppliers)
+	if err != nil {
+		return err
+	}
+	return a.Repository.SaveSuppliers(data)
+}
+
+func findSupplier(id string, suppliers []*domain.Supplier) *domain.Supplier {
+	for _, supplier := range suppliers {
+		if supplier.ID == id {
+			return supplier
+		}
+	}
+	return nil
+}
+
+func findSupplierItem(ingredientID string, supplier *domain.Supplier) *domain.SupplierItem {
+	for i := range supplier.Items {
+		if supplier.Items[i].IngredientID == ingredientID {
+			return &supplier.Items[i]
+		}
+	}
+	return nil
+}
+
+func validateSupplier(supplier *domain.Supplier) error {
+	if supplier.ID == "" {
+		return errors.New("supplier ID is required")
+	}
+	if supplier.Name == "" {
+		return errors.New("supplier name is required")
+	}
+	if supplier.LeadTimeDays < 0 {
+		return errors.New("lead time must not be negative")
+	}
+
+	seen := make(map[string]bool, len(supplier.Items))
+	for _, item := range supplier.Items {
+		if item.IngredientID == "" {
+			return fmt.Errorf("ingredient ID is required for supplier %s items", supplier.ID)
+		}
+		if seen[item.IngredientID] {
+			return fmt.Errorf("ingredient %s is listed more than once for supplier %s", item.IngredientID, supplier.ID)
+		}
+		seen[item.IngredientID] = true
+		if item.PackSize <= 0 {
+			return fmt.Errorf("pack size for ingredient %s must be greater than zero", item.IngredientID)
+		}
+		if item.PackPrice < 0 {
+			return fmt.Errorf("pack price for ingredient %s must not be negative", item.IngredientID)
+		}
+		if item.Unit != "" {
+			if _, ok := domain.LookupUnit(item.Unit); !ok {
+				return fmt.Errorf("ingredient %s has unknown unit %q", item.IngredientID, item.Unit)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+package usecase
+
+import (
+	"strings"
+	"time"
+
+	"hot-coffee/internal/domain"
+)
+
+// Дни недели отчета о загрузке, начиная с понедельника
+var trafficWeekdays = []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday, time.Sunday}
+
+// trafficTotals накапливает показатели ячейки или часа отчета о загрузке
+type trafficTotals struct {
+	orders      int
+	items       int
+	revenue     float64
+	prepTime    time.Duration
+	prepSamples int
+}
+
+func (t *trafficTotals) averageItems() float64 {
+	if t.orders == 0 {
+		return 0
+	}
+	return float64(t.items) / float64(t.orders)
+}
+
+// GetTrafficReport распределяет завершенные заказы, созданные в период [from, to), по дням недели и часам в часовом поясе loc.
+// Время приготовления считается от создания до закрытия заказа; для заказов без времени закрытия берется время списания ингредиентов.
+func (a *Application) GetTrafficReport(from, to time.Time, loc *time.Location) (*domain.TrafficReport, error) {
+	orders, err := a.getOrders()
+	if err != nil {
+		return nil, err
+	}
+
+	menuItems, err := a.getMenuItems()
+	if err != nil {
+		return nil, err
+	}
+
+	ledger, err := a.getMovements()
+	if err != nil {
+		return nil, err
+	}
+
+	// Время списания ингредиентов по заказам, закрытым до появления времени закрытия
+	soldAt := make(map[string]time.Time)
+	for _, movement := range ledger {
+		if movement.Type != domain.MovementSale || movement.OrderID == "" {
+			continue
+		}
+		if at, ok := soldAt[movement.OrderID]; !ok || movement.CreatedAt.Before(at) {
+			soldAt[movement.OrderID] = movement.CreatedAt
+		}
+	}
+
+	var cells [7][24]trafficTotals
+	var hours [24]trafficTotals
+	for _, order := range orders {
+		if order.Status != domain.StatusCompleted || !inPeriod(order.CreatedAt, from, to) {
+			continue
+		}
+
+		createdAt := order.CreatedAt.In(loc)
+		cell := &cells[(int(createdAt.Weekday())+6)%7][createdAt.Hour()]
+		hour := &hours[createdAt.Hour()]
+		cell.orders++
+		hour.orders++
+
+		for _, item := range order.Items {
+			cell.items += item.Quantity
+			hour.items += item.Quantity
+			// Выручка удаленных из меню товаров без зафиксированной цены неизвестна
+			if price, ok := item.UnitPrice(menuLookup(menuItems)); ok {
+				revenue := float64(item.Quantity) * price
+				cell.revenue += revenue
+				hour.revenue += revenue
+			}
+		}
+
+		completedAt, ok := soldAt[order.ID]
+		if order.CompletedAt != nil {
+			completedAt, ok = *order.CompletedAt, true
+		}
+		if ok && !completedAt.Before(order.CreatedAt) {
+			hour.prepTime += completedAt.Sub(order.CreatedAt)
+			hour.prepSamples++
+		}
+	}
+
+	report := &domain.TrafficReport{
+		From:     optionalTime(from),
+		To:       optionalTime(to),
+		Timezone: loc.String(),
+		Cells:    make([]domain.TrafficCell, 0, 7*24),
+		Hours:    make([]domain.TrafficHour, 0, 24),
+	}
+	for day, weekday := range trafficWeekdays {
+		for hour := range 24 {
+			totals := &cells[day][hour]
+			report.Cells = append(report.Cells, domain.TrafficCell{
+				Weekday:      strings.ToLower(weekday.String()),
+				Hour:         hour,
+				Orders:       totals.orders,
+				Revenue:      roundMoney(totals.revenue),
+				AverageItems: totals.averageItems(),
+			})
+		}
+	}
+	for hour := range 24 {
+		totals := &hours[hour]
+		item := domain.TrafficHour{
+			Hour:         hour,
+			Orders:       totals.orders,
+			Revenue:      roundMoney(totals.revenue),
+			AverageItems: totals.averageItems(),
+		}
+		if totals.prepSamples > 0 {
+			minutes := totals.prepTime.Minutes() / float64(totals.prepSamples)
+			item.AveragePrepMinutes = &minutes
+		}
+		report.Hours = append(report.Hours, item)
+	}
+	return report, nil
+}
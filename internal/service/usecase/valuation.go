@@ -0,0 +1,302 @@
+package usecase
+
+import (
+	"slices"
+	"sort"
+	"time"
+
+	"hot-coffee/internal/domain"
+)
+
+// stockValue — количество и стоимость остатка ингредиента по журналу движений
+type stockValue struct {
+	quantity float64
+	value    float64
+	// Слои стоимости в порядке поступления для расчета FIFO
+	layers []costLayer
+}
+
+// costLayer — часть остатка, поступившая по одной цене
+type costLayer struct {
+	quantity float64
+	unitCost float64
+}
+
+// unitCost возвращает среднюю себестоимость единицы остатка
+func (v *stockValue) unitCost() float64 {
+	if v.quantity <= driftEpsilon {
+		return 0
+	}
+	return v.value / v.quantity
+}
+
+// costOf возвращает стоимость quantity единиц по средней себестоимости.
+// Списание всего остатка забирает всю его стоимость.
+func (v *stockValue) costOf(quantity float64) float64 {
+	if quantity >= v.quantity-driftEpsilon {
+		return max(v.value, 0)
+	}
+	return quantity * v.unitCost()
+}
+
+// fifoCostOf возвращает стоимость quantity единиц по слоям в порядке поступления.
+// Количество сверх слоев оценивается по средней себестоимости; списание всего остатка забирает всю его стоимость.
+func (v *stockValue) fifoCostOf(quantity float64) float64 {
+	if quantity >= v.quantity-driftEpsilon {
+		return max(v.value, 0)
+	}
+	cost := 0.0
+	for _, layer := range v.layers {
+		if quantity <= 0 {
+			break
+		}
+		taken := min(layer.quantity, quantity)
+		cost += taken * layer.unitCost
+		quantity -= taken
+	}
+	return cost + max(quantity, 0)*v.unitCost()
+}
+
+func (v *stockValue) apply(movement *domain.StockMovement) {
+	v.quantity += movement.Delta
+	v.value += movement.Cost
+	switch {
+	case movement.Delta > 0:
+		v.layers = append(v.layers, costLayer{quantity: movement.Delta, unitCost: movement.Cost / movement.Delta})
+	case movement.Delta < 0:
+		v.consumeLayers(-movement.Delta)
+	}
+	if v.quantity <= driftEpsilon {
+		v.value = 0
+		v.layers = nil
+	}
+}
+
+// consumeLayers уменьшает слои стоимости на quantity, начиная с самых ранних поступлений
+func (v *stockValue) consumeLayers(quantity float64) {
+	for len(v.layers) > 0 && quantity > driftEpsilon {
+		if v.layers[0].quantity > quantity {
+			v.layers[0].quantity -= quantity
+			return
+		}
+		quantity -= v.layers[0].quantity
+		v.layers = v.layers[1:]
+	}
+}
+
+// ledgerValues рассчитывает остатки и их стоимость по движениям, записанным до момента before.
+// Нулевой before учитывает весь журнал.
+func ledgerValues(ledger []*domain.StockMovement, before time.Time) map[string]*stockValue {
+	values := make(map[string]*stockValue)
+	for _, movement := range ledger {
+		if !before.IsZero() && !movement.CreatedAt.Before(before) {
+			continue
+		}
+		value := values[movement.IngredientID]
+		if value == nil {
+			value = &stockValue{}
+			values[movement.IngredientID] = value
+		}
+		value.apply(movement)
+	}
+	return values
+}
+
+// GetInventoryValuation оценивает запасы за период [from, to):
+// стоимость на начало, поступления, себестоимость продаж, возвраты заказов на склад, отходы, корректировки и стоимость на конец
+func (a *Application) GetInventoryValuation(from, to time.Time) (*domain.InventoryValuation, error) {
+	inventoryItems, err := a.getInventoryItems()
+	if err != nil {
+		return nil, err
+	}
+
+	ledger, err := a.getMovements()
+	if err != nil {
+		return nil, err
+	}
+
+	// Без начала периода остаток на начало нулевой: весь журнал попадает в период
+	opening := make(map[string]*stockValue)
+	if !from.IsZero() {
+		opening = ledgerValues(ledger, from)
+	}
+	byIngredient := make(map[string]*domain.IngredientValuation)
+	valuation := func(ingredientID string) *domain.IngredientValuation {
+		item, ok := byIngredient[ingredientID]
+		if !ok {
+			item = &domain.IngredientValuation{IngredientID: ingredientID}
+			if inventoryItem := findInventoryItem(ingredientID, inventoryItems); inventoryItem != nil {
+				item.Name = inventoryItem.Name
+				item.Unit = inventoryItem.Unit
+			}
+			if value := opening[ingredientID]; value != nil {
+				item.OpeningQuantity = value.quantity
+				item.OpeningValue = value.value
+			}
+			byIngredient[ingredientID] = item
+		}
+		return item
+	}
+
+	for ingredientID := range opening {
+		valuation(ingredientID)
+	}
+	for _, item := range inventoryItems {
+		valuation(item.IngredientID)
+	}
+
+	closing := make(map[string]*stockValue, len(opening))
+	for ingredientID, value := range opening {
+		copied := *value
+		copied.layers = slices.Clone(value.layers)
+		closing[ingredientID] = &copied
+	}
+
+	for _, movement := range ledger {
+		if !inPeriod(movement.CreatedAt, from, to) {
+			continue
+		}
+		item := valuation(movement.IngredientID)
+		switch {
+		case movement.Type == domain.MovementRestock && movement.Delta > 0:
+			item.ReceivedQuantity += movement.Delta
+			item.ReceivedValue += movement.Cost
+		case movement.Type == domain.MovementSale:
+			item.SoldQuantity -= movement.Delta
+			item.COGS -= movement.Cost
+		case movement.Type == domain.MovementReturn:
+			item.ReturnedQuantity += movement.Delta
+			item.ReturnedValue += movement.Cost
+		case movement.Type == domain.MovementWaste:
+			item.WastedQuantity -= movement.Delta
+			item.WasteValue -= movement.Cost
+		default:
+			item.AdjustmentValue += movement.Cost
+		}
+
+		value := closing[movement.IngredientID]
+		if value == nil {
+			value = &stockValue{}
+			closing[movement.IngredientID] = value
+		}
+		value.apply(movement)
+	}
+
+	report := &domain.InventoryValuation{
+		Method: a.costingMethod(),
+		From:   optionalTime(from),
+		To:     optionalTime(to),
+		Items:  make([]domain.IngredientValuation, 0, len(byIngredient)),
+	}
+	for ingredientID, item := range byIngredient {
+		if value := closing[ingredientID]; value != nil {
+			item.ClosingQuantity = value.quantity
+			item.ClosingValue = value.value
+			item.UnitCost = value.unitCost()
+		}
+		report.OpeningValue += item.OpeningValue
+		report.ClosingValue += item.ClosingValue
+		report.Items = append(report.Items, *item)
+	}
+	sort.Slice(report.Items, func(i, j int) bool {
+		if report.Items[i].ClosingValue != report.Items[j].ClosingValue {
+			return report.Items[i].ClosingValue > report.Items[j].ClosingValue
+		}
+		return report.Items[i].IngredientID < report.Items[j].IngredientID
+	})
+	return report, nil
+}
+
+// GetCOGSReport рассчитывает себестоимость продаж за период [from, to) по ингредиентам и позициям меню.
+// Ингредиенты, возвращенные на склад с заказами, уменьшают себестоимость по своей исходной стоимости.
+func (a *Application) GetCOGSReport(from, to time.Time) (*domain.COGSReport, error) {
+	inventoryItems, err := a.getInventoryItems()
+	if err != nil {
+		return nil, err
+	}
+
+	menuItems, err := a.getMenuItems()
+	if err != nil {
+		return nil, err
+	}
+
+	ledger, err := a.getMovements()
+	if err != nil {
+		return nil, err
+	}
+
+	byIngredient := make(map[string]*domain.IngredientCOGS)
+	byProduct := make(map[string]*domain.ProductCOGS)
+	report := &domain.COGSReport{
+		Method: a.costingMethod(),
+		From:   optionalTime(from),
+		To:     optionalTime(to),
+	}
+	for _, movement := range ledger {
+		if movement.Type != domain.MovementSale && movement.Type != domain.MovementReturn || !inPeriod(movement.CreatedAt, from, to) {
+			continue
+		}
+		cost := -movement.Cost
+		report.Total += cost
+		if movement.Type == domain.MovementReturn {
+			report.Returned += movement.Cost
+		}
+
+		ingredient, ok := byIngredient[movement.IngredientID]
+		if !ok {
+			ingredient = &domain.IngredientCOGS{IngredientID: movement.IngredientID}
+			if item := findInventoryItem(movement.IngredientID, inventoryItems); item != nil {
+				ingredient.Name = item.Name
+				ingredient.Unit = item.Unit
+			}
+			byIngredient[movement.IngredientID] = ingredient
+		}
+		ingredient.Quantity -= movement.Delta
+		ingredient.Cost += cost
+
+		if movement.ProductID == "" {
+			continue
+		}
+		product, ok := byProduct[movement.ProductID]
+		if !ok {
+			product = &domain.ProductCOGS{ProductID: movement.ProductID}
+			if menuItem := findMenuItem(movement.ProductID, menuItems); menuItem != nil {
+				product.Name = menuItem.Name
+			}
+			byProduct[movement.ProductID] = product
+		}
+		product.Cost += cost
+	}
+
+	report.ByIngredient = make([]domain.IngredientCOGS, 0, len(byIngredient))
+	for _, ingredient := range byIngredient {
+		report.ByIngredient = append(report.ByIngredient, *ingredient)
+	}
+	sort.Slice(report.ByIngredient, func(i, j int) bool {
+		if report.ByIngredient[i].Cost != report.ByIngredient[j].Cost {
+			return report.ByIngredient[i].Cost > report.ByIngredient[j].Cost
+		}
+		return report.ByIngredient[i].IngredientID < report.ByIngredient[j].IngredientID
+	})
+
+	report.ByProduct = make([]domain.ProductCOGS, 0, len(byProduct))
+	for _, product := range byProduct {
+		report.ByProduct = append(report.ByProduct, *product)
+	}
+	sort.Slice(report.ByProduct, func(i, j int) bool {
+		if report.ByProduct[i].Cost != report.ByProduct[j].Cost {
+			return report.ByProduct[i].Cost > report.ByProduct[j].Cost
+		}
+		return report.ByProduct[i].ProductID < report.ByProduct[j].ProductID
+	})
+
+	return report, nil
+}
+
+// optionalTime возвращает nil для нулевой границы периода
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
@@ -0,0 +1,259 @@
+package usecase
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"hot-coffee/internal/domain"
+)
+
+// WasteIngredient списывает ингредиент в отходы с указанием причины
+func (a *Application) WasteIngredient(data []byte, user string) ([]byte, int, error) {
+	var waste domain.IngredientWaste
+	if err := json.Unmarshal(data, &waste); err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("invalid waste data")
+	}
+
+	if waste.IngredientID == "" {
+		return nil, http.StatusBadRequest, errors.New("ingredient ID is required")
+	}
+	if waste.Quantity <= 0 {
+		return nil, http.StatusBadRequest, errors.New("quantity must be greater than zero")
+	}
+	if !waste.Reason.IsValid() {
+		return nil, http.StatusBadRequest, fmt.Errorf("invalid waste reason: %q", waste.Reason)
+	}
+
+	a.Repository.Lock()
+	defer a.unlockAndNotify()
+
+	inventoryItems, err := a.getInventoryItems()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	item := findInventoryItem(waste.IngredientID, inventoryItems)
+	if item == nil {
+		return nil, http.StatusNotFound, fmt.Errorf("inventory item with Ingredient ID %s not found", waste.IngredientID)
+	}
+
+	quantity, err := toInventoryUnit(waste.Quantity, waste.Unit, item)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	if item.Quantity < quantity {
+		return nil, http.StatusConflict, fmt.Errorf("insufficient quantity for ingredient %s", item.IngredientID)
+	}
+	item.Quantity -= quantity
+
+	if err := a.saveInventoryItems(inventoryItems); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	movement := newMovement(item.IngredientID, domain.MovementWaste, -quantity, waste.Note, "", user)
+	movement.WasteReason = waste.Reason
+	if err := a.recordMovements(movement); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	a.evaluateStockLevels(inventoryItems)
+
+	result, err := json.Marshal(movement)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	return result, http.StatusCreated, nil
+}
+
+// WasteMenuItem списывает приготовленные позиции меню: расходуются ингредиенты по рецепту
+func (a *Application) WasteMenuItem(id string, data []byte, user string) ([]byte, int, error) {
+	var waste domain.MenuItemWaste
+	if err := json.Unmarshal(data, &waste); err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("invalid waste data")
+	}
+
+	if waste.Quantity <= 0 {
+		return nil, http.StatusBadRequest, errors.New("quantity must be greater than zero")
+	}
+	if !waste.Reason.IsValid() {
+		return nil, http.StatusBadRequest, fmt.Errorf("invalid waste reason: %q", waste.Reason)
+	}
+
+	a.Repository.Lock()
+	defer a.unlockAndNotify()
+
+	menuData, err := a.Repository.GetMenuItems()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	menuItems, err := a.Repository.UnmarshalJsonMenuItems(menuData)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	menuItem := findMenuItem(id, menuItems)
+	if menuItem == nil {
+		return nil, http.StatusNotFound, fmt.Errorf("menu item with ID %s not found", id)
+	}
+
+	inventoryItems, err := a.getInventoryItems()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	// Комбо со слотами выбора списывается по компонентам
+	recipe, err := orderItemRecipe(domain.OrderItem{ProductID: menuItem.ID, Quantity: waste.Quantity}, menuItem, menuItems)
+	if err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("cannot write off menu item %s: %w", menuItem.ID, err)
+	}
+
+	movements := make([]*domain.StockMovement, 0, len(recipe))
+	for _, ingredient := range recipe {
+		amount, err := decrementInventory(ingredient, waste.Quantity, inventoryItems, nil)
+		if err != nil {
+			return nil, http.StatusConflict, fmt.Errorf("cannot write off menu item %s: %w", menuItem.ID, err)
+		}
+		movement := newMovement(ingredient.IngredientID, domain.MovementWaste, -amount, waste.Note, "", user)
+		movement.WasteReason = waste.Reason
+		movement.ProductID = menuItem.ID
+		movements = append(movements, movement)
+	}
+
+	if err := a.saveInventoryItems(inventoryItems); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if err := a.recordMovements(movements...); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	a.evaluateStockLevels(inventoryItems)
+
+	result, err := a.Repository.MarshalJsonMovements(movements)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	return result, http.StatusCreated, nil
+}
+
+// GetWasteReport формирует отчет об отходах за период [from, to) по ингредиентам и причинам
+func (a *Application) GetWasteReport(from, to time.Time) (*domain.WasteReport, error) {
+	ledger, err := a.getMovements()
+	if err != nil {
+		return nil, err
+	}
+
+	inventoryItems, err := a.getInventoryItems()
+	if err != nil {
+		return nil, err
+	}
+
+	unitCosts, err := a.ingredientUnitCosts(inventoryItems)
+	if err != nil {
+		return nil, err
+	}
+
+	byIngredient := make(map[string]*domain.IngredientWasted)
+	byReason := make(map[domain.WasteReason]*domain.ReasonWasted)
+	report := &domain.WasteReport{}
+	for _, movement := range ledger {
+		if movement.Type != domain.MovementWaste || !inPeriod(movement.CreatedAt, from, to) {
+			continue
+		}
+
+		quantity := -movement.Delta
+		cost := -movement.Cost
+		if movement.Cost == 0 {
+			cost = quantity * unitCosts[movement.IngredientID]
+		}
+		report.TotalCost += cost
+
+		ingredient, ok := byIngredient[movement.IngredientID]
+		if !ok {
+			ingredient = &domain.IngredientWasted{IngredientID: movement.IngredientID}
+			if item := findInventoryItem(movement.IngredientID, inventoryItems); item != nil {
+				ingredient.Name = item.Name
+				ingredient.Unit = item.Unit
+			}
+			byIngredient[movement.IngredientID] = ingredient
+		}
+		ingredient.Quantity += quantity
+		ingredient.Cost += cost
+
+		reason := movement.WasteReason
+		if reason == "" {
+			reason = domain.WasteOther
+		}
+		reasonWasted, ok := byReason[reason]
+		if !ok {
+			reasonWasted = &domain.ReasonWasted{Reason: reason}
+			byReason[reason] = reasonWasted
+		}
+		reasonWasted.Entries++
+		reasonWasted.Cost += cost
+	}
+
+	report.ByIngredient = make([]domain.IngredientWasted, 0, len(byIngredient))
+	for _, ingredient := range byIngredient {
+		report.ByIngredient = append(report.ByIngredient, *ingredient)
+	}
+	sort.Slice(report.ByIngredient, func(i, j int) bool {
+		if report.ByIngredient[i].Cost != report.ByIngredient[j].Cost {
+			return report.ByIngredient[i].Cost > report.ByIngredient[j].Cost
+		}
+		return report.ByIngredient[i].IngredientID < report.ByIngredient[j].IngredientID
+	})
+
+	report.ByReason = make([]domain.ReasonWasted, 0, len(byReason))
+	for _, reason := range byReason {
+		report.ByReason = append(report.ByReason, *reason)
+	}
+	sort.Slice(report.ByReason, func(i, j int) bool {
+		if report.ByReason[i].Cost != report.ByReason[j].Cost {
+			return report.ByReason[i].Cost > report.ByReason[j].Cost
+		}
+		return report.ByReason[i].Reason < report.ByReason[j].Reason
+	})
+
+	return report, nil
+}
+
+// ingredientUnitCosts оценивает стоимость единицы ингредиента по средней себестоимости остатка,
+// а для ингредиентов без стоимости в журнале — по самой низкой цене поставщиков
+func (a *Application) ingredientUnitCosts(inventoryItems []*domain.InventoryItem) (map[string]float64, error) {
+	suppliers, err := a.getSuppliers()
+	if err != nil {
+		return nil, err
+	}
+
+	ledger, err := a.getMovements()
+	if err != nil {
+		return nil, err
+	}
+	values := ledgerValues(ledger, time.Time{})
+
+	costs := make(map[string]float64, len(inventoryItems))
+	for _, item := range inventoryItems {
+		if value := values[item.IngredientID]; value != nil && value.unitCost() > 0 {
+			costs[item.IngredientID] = value.unitCost()
+			continue
+		}
+		_, supplierItem, packSize := cheapestSupplier(item, suppliers)
+		if supplierItem != nil {
+			costs[item.IngredientID] = supplierItem.PackPrice / packSize
+		}
+	}
+	return costs, nil
+}
+
+// inPeriod проверяет, попадает ли момент в период [from, to); нулевые границы не ограничивают период
+func inPeriod(t, from, to time.Time) bool {
+	if !from.IsZero() && t.Before(from) {
+		return false
+	}
+	if !to.IsZero() && !t.Before(to) {
+		return false
+	}
+	return true
+}
@@ -9,7 +9,9 @@ import (
 
 	"hot-coffee/internal/config"
 	jsondb "hot-coffee/internal/dal/jsonDB"
+	"hot-coffee/internal/domain"
 	"hot-coffee/internal/handler"
+	"hot-coffee/internal/notifier"
 	"hot-coffee/internal/service/usecase"
 )
 
@@ -24,6 +26,18 @@ func main() {
 	repo := jsondb.NewJsonDB()
 	logg.InfoLogger.Println("Initialized JSON DB repository")
 	service := usecase.NewApplication(repo)
+	service.Logger = logg.InfoLogger
+	service.Notifier = newNotifier()
+	service.CostingMethod = domain.CostingMethod(config.CostingMethod)
+	service.TaxRate = config.TaxRate
+	if err := service.InitLedger(); err != nil {
+		log.Fatalf("Failed to init stock ledger: %v", err)
+	}
+	if err := service.InitSearchIndex(); err != nil {
+		log.Fatalf("Failed to build search index: %v", err)
+	}
+	go service.RunNotifier()
+	go service.RunExpiryWriteOff(config.ExpiryCheckInterval)
 	logg.InfoLogger.Println("Application service initialized")
 	handlerHTTP := handler.NewCustomHandler(service)
 	logg.InfoLogger.Println("HTTP Handler created")
@@ -39,3 +53,18 @@ func main() {
 		log.Fatalf("Failed to start server: %v", err)
 	}
 }
+
+// Собираем получателей уведомлений о низких остатках из флагов
+func newNotifier() notifier.Notifier {
+	var notifiers notifier.MultiNotifier
+	if config.NotifyWebhook != "" {
+		notifiers = append(notifiers, notifier.NewWebhookNotifier(config.NotifyWebhook))
+	}
+	if config.NotifyFile != "" {
+		notifiers = append(notifiers, notifier.NewFileNotifier(config.NotifyFile))
+	}
+	if len(notifiers) == 0 {
+		return nil
+	}
+	return notifiers
+}